@@ -0,0 +1,364 @@
+package geoutil
+
+import (
+    "math"
+    "sort"
+)
+
+// AreaModel is a reference surface that can measure polygons
+type AreaModel interface {
+    EarthModel
+    SignedArea(polygon []Point) float64 // Counter-clockwise positive area in square kilometers
+    Perimeter(polygon []Point) float64  // Ring length in kilometers
+}
+
+var (
+    _ AreaModel = (*Ellipsoid)(nil)
+    _ AreaModel = Sphere{}
+)
+
+// unitSphere solves geodesics on a sphere of radius 1 for spherical areas
+var unitSphere = newGeodesic(1, 0)
+
+// PolygonArea calculates the area of a ring on the WGS84 ellipsoid
+// polygon: Ring vertices, open or closed, in either winding order
+// Returns: Area in square kilometers
+func PolygonArea(polygon []Point) float64 {
+    return WGS84.Area(polygon)
+}
+
+// PolygonPerimeter calculates the length of a ring on the WGS84 ellipsoid
+// polygon: Ring vertices, open or closed
+// Returns: Perimeter in kilometers
+func PolygonPerimeter(polygon []Point) float64 {
+    return WGS84.Perimeter(polygon)
+}
+
+// Area calculates the geodesic area of a ring using Karney's algorithm
+// The ring is taken to enclose the smaller of the two regions it bounds
+// polygon: Ring vertices, open or closed, in either winding order
+// Returns: Area in square kilometers
+func (e *Ellipsoid) Area(polygon []Point) float64 {
+    return math.Abs(e.SignedArea(polygon))
+}
+
+// SignedArea calculates the geodesic area of a ring with its winding
+// polygon: Ring vertices, open or closed
+// Returns: Area in square kilometers, positive for counter-clockwise rings
+func (e *Ellipsoid) SignedArea(polygon []Point) float64 {
+    return e.orDefault().geod.ringArea(polygon)
+}
+
+// Perimeter calculates the length of a ring from geodesic distances
+// polygon: Ring vertices, open or closed
+// Returns: Perimeter in kilometers
+func (e *Ellipsoid) Perimeter(polygon []Point) float64 {
+    return ringPerimeter(polygon, e.Distance)
+}
+
+// Area calculates the area of a ring with great-circle edges
+// The ring is taken to enclose the smaller of the two regions it bounds
+// polygon: Ring vertices, open or closed, in either winding order
+// Returns: Area in square kilometers
+func (s Sphere) Area(polygon []Point) float64 {
+    return math.Abs(s.SignedArea(polygon))
+}
+
+// SignedArea calculates the area of a ring with great-circle edges with its winding
+// polygon: Ring vertices, open or closed
+// Returns: Area in square kilometers, positive for counter-clockwise rings
+func (s Sphere) SignedArea(polygon []Point) float64 {
+    return sq(s.Radius) * unitSphere.ringArea(polygon)
+}
+
+// Perimeter calculates the length of a ring from great-circle distances
+// polygon: Ring vertices, open or closed
+// Returns: Perimeter in kilometers
+func (s Sphere) Perimeter(polygon []Point) float64 {
+    return ringPerimeter(polygon, s.Distance)
+}
+
+// Area calculates the area of the outer ring minus its holes
+// m: Surface to measure on, e.g. WGS84 or EarthSphere
+// Returns: Area in square kilometers
+func (pg Polygon) Area(m AreaModel) float64 {
+    area := math.Abs(m.SignedArea(pg.Outer))
+    for _, hole := range pg.Holes {
+        area -= math.Abs(m.SignedArea(hole))
+    }
+    return math.Max(0, area)
+}
+
+// Perimeter calculates the total length of the outer ring and holes
+// m: Surface to measure on
+// Returns: Perimeter in kilometers
+func (pg Polygon) Perimeter(m AreaModel) float64 {
+    perimeter := m.Perimeter(pg.Outer)
+    for _, hole := range pg.Holes {
+        perimeter += m.Perimeter(hole)
+    }
+    return perimeter
+}
+
+// Area calculates the total area of all polygons
+// m: Surface to measure on
+// Returns: Area in square kilometers
+func (mp MultiPolygon) Area(m AreaModel) float64 {
+    area := 0.0
+    for _, pg := range mp {
+        area += pg.Area(m)
+    }
+    return area
+}
+
+// Perimeter calculates the total length of all rings
+// m: Surface to measure on
+// Returns: Perimeter in kilometers
+func (mp MultiPolygon) Perimeter(m AreaModel) float64 {
+    perimeter := 0.0
+    for _, pg := range mp {
+        perimeter += pg.Perimeter(m)
+    }
+    return perimeter
+}
+
+// PolygonCentroid calculates the center of mass of a ring on the sphere
+// polygon: Ring vertices, open or closed, in either winding order
+// Returns: Centroid; it may lie outside a concave ring
+func PolygonCentroid(polygon []Point) Point {
+    return Polygon{Outer: polygon}.Centroid()
+}
+
+// PolygonPointOnSurface finds a point guaranteed to lie inside a ring
+// polygon: Ring vertices, open or closed
+// Returns: Interior point, e.g. for placing labels
+func PolygonPointOnSurface(polygon []Point) Point {
+    return Polygon{Outer: polygon}.PointOnSurface()
+}
+
+// Centroid calculates the center of mass of the polygon area on the sphere
+// Edges are treated as great-circle arcs and holes are subtracted
+// Returns: Centroid; it may lie outside a concave polygon
+func (pg Polygon) Centroid() Point {
+    return momentPoint(pg.moment(), pg.Outer)
+}
+
+// Centroid calculates the center of mass of all polygons on the sphere
+// Returns: Centroid; it may lie outside every member
+func (mp MultiPolygon) Centroid() Point {
+    var m vec3
+    var verts []Point
+    for _, pg := range mp {
+        m = m.add(pg.moment())
+        verts = append(verts, pg.Outer...)
+    }
+    return momentPoint(m, verts)
+}
+
+// PointOnSurface finds a point guaranteed to lie inside the polygon area
+// Returns the centroid when it is inside, otherwise the middle of the widest
+// interior span along a parallel, tried at several latitudes
+func (pg Polygon) PointOnSurface() Point {
+    c := pg.Centroid()
+    if len(pg.Outer) < 3 || pg.Contains(c) {
+        return c
+    }
+
+    outer := pg.Outer
+    if pg.Mode != ContainmentPlanar {
+        outer = unwrapRing(pg.Outer).points
+    }
+    box := ringRect(outer)
+    rings := [][]Point{outer}
+    for _, hole := range pg.Holes {
+        if len(hole) == 0 {
+            continue
+        }
+        if pg.Mode != ContainmentPlanar {
+            // Move each hole next to the unwrapped outer ring
+            hole = unwrapRing(hole).points
+            shift := 360 * math.Round((box.centerLon()-ringRect(hole).centerLon())/360)
+            shifted := make([]Point, len(hole))
+            for i, p := range hole {
+                shifted[i] = Point{Lat: p.Lat, Lon: p.Lon + shift}
+            }
+            hole = shifted
+        }
+        rings = append(rings, hole)
+    }
+
+    // Scan at 1/2, 1/4, 3/4, 1/8, 3/8, ... of the latitude range
+    for den := 2; den <= 64; den *= 2 {
+        for num := 1; num < den; num += 2 {
+            lat := box.minLat + (box.maxLat-box.minLat)*float64(num)/float64(den)
+            if p, ok := widestSpanMidpoint(rings, lat); ok && pg.Contains(p) {
+                return p
+            }
+        }
+    }
+    return c
+}
+
+// PointOnSurface finds a point inside the largest polygon
+// Returns: Interior point, or the zero Point for an empty multipolygon
+func (mp MultiPolygon) PointOnSurface() Point {
+    best, bestArea := -1, -1.0
+    for i, pg := range mp {
+        if area := pg.Area(EarthSphere); area > bestArea {
+            best, bestArea = i, area
+        }
+    }
+    if best < 0 {
+        return Point{}
+    }
+    return mp[best].PointOnSurface()
+}
+
+// widestSpanMidpoint intersects planar rings with a parallel and returns the
+// middle of the widest span between alternating crossings
+func widestSpanMidpoint(rings [][]Point, lat float64) (Point, bool) {
+    var xs []float64
+    for _, ring := range rings {
+        for i := range ring {
+            a, b := ring[i], ring[(i+1)%len(ring)]
+            if (a.Lat > lat) != (b.Lat > lat) {
+                xs = append(xs, a.Lon+(lat-a.Lat)*(b.Lon-a.Lon)/(b.Lat-a.Lat))
+            }
+        }
+    }
+    sort.Float64s(xs)
+
+    best, width := 0.0, 0.0
+    for i := 0; i+1 < len(xs); i += 2 {
+        if w := xs[i+1] - xs[i]; w > width {
+            best, width = (xs[i]+xs[i+1])/2, w
+        }
+    }
+    if width == 0 {
+        return Point{}, false
+    }
+    return Point{Lat: lat, Lon: angNormalize(best)}, true
+}
+
+// moment returns the area-weighted position vector of the polygon on the unit sphere
+func (pg Polygon) moment() vec3 {
+    m := ringMoment(pg.Outer)
+    for _, hole := range pg.Holes {
+        m = m.add(ringMoment(hole).scale(-1))
+    }
+    return m
+}
+
+// ringMoment integrates the position vector over the area enclosed by a ring
+// By Stokes' theorem the integral is half the sum of edge angles times edge normals
+// The result is oriented so that the enclosed area is the smaller region
+func ringMoment(ring []Point) vec3 {
+    ring = openRing(ring)
+    if len(ring) < 3 {
+        return vec3{}
+    }
+    var m vec3
+    for i := range ring {
+        a, b := toVec3(ring[i]), toVec3(ring[(i+1)%len(ring)])
+        n := a.cross(b)
+        if n.norm() == 0 {
+            continue
+        }
+        θ := math.Atan2(n.norm(), a.dot(b))
+        m = m.add(n.scale(θ / (2 * n.norm())))
+    }
+    if unitSphere.ringArea(ring) < 0 {
+        m = m.scale(-1)
+    }
+    return m
+}
+
+// momentPoint converts an area moment to a point, falling back to the vertex mean
+func momentPoint(m vec3, verts []Point) Point {
+    if m.norm() < 1e-15 {
+        m = vec3{}
+        for _, p := range verts {
+            m = m.add(toVec3(p))
+        }
+        if m.norm() == 0 {
+            return Point{}
+        }
+    }
+    return Point{
+        Lat: math.Atan2(m.z, math.Hypot(m.x, m.y)) * radToDeg,
+        Lon: math.Atan2(m.y, m.x) * radToDeg,
+    }
+}
+
+// ringArea sums the areas between each edge and the equator, with corrections
+// for edges crossing the prime meridian (Karney's PolygonArea)
+// Returns: Area in the semi-major axis unit squared, positive for counter-clockwise rings
+func (g *geodesic) ringArea(ring []Point) float64 {
+    ring = openRing(ring)
+    if len(ring) < 3 {
+        return 0
+    }
+
+    sum := 0.0
+    crossings := 0
+    for i := range ring {
+        a, b := ring[i], ring[(i+1)%len(ring)]
+        _, _, _, _, _, S12 := g.genInverse(a.Lat, a.Lon, b.Lat, b.Lon, true)
+        sum += S12
+        crossings += transit(a.Lon, b.Lon)
+    }
+
+    area0 := 4 * math.Pi * g.c2
+    sum = math.Remainder(sum, area0)
+    if crossings&1 != 0 {
+        if sum < 0 {
+            sum += area0 / 2
+        } else {
+            sum -= area0 / 2
+        }
+    }
+    // The sum is clockwise positive; report counter-clockwise positive in (-area0/2, area0/2]
+    sum = -sum
+    if sum > area0/2 {
+        sum -= area0
+    } else if sum <= -area0/2 {
+        sum += area0
+    }
+    return sum
+}
+
+// transit counts crossings of the prime meridian by the edge lon1 -> lon2
+// Returns: 1 for eastward, -1 for westward, 0 otherwise
+func transit(lon1, lon2 float64) int {
+    lon12, _ := angDiff(lon1, lon2)
+    lon1 = angNormalize(lon1)
+    lon2 = angNormalize(lon2)
+    switch {
+    case lon12 > 0 && ((lon1 < 0 && lon2 >= 0) || (lon1 > 0 && lon2 == 0)):
+        return 1
+    case lon12 < 0 && lon1 >= 0 && lon2 < 0:
+        return -1
+    }
+    return 0
+}
+
+// ringPerimeter sums edge lengths including the closing edge
+func ringPerimeter(ring []Point, distance func(p1, p2 Point) float64) float64 {
+    ring = openRing(ring)
+    if len(ring) < 2 {
+        return 0
+    }
+    perimeter := 0.0
+    for i := range ring {
+        perimeter += distance(ring[i], ring[(i+1)%len(ring)])
+    }
+    return perimeter
+}
+
+// openRing drops a closing vertex equal to the first one
+func openRing(ring []Point) []Point {
+    if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+        return ring[:len(ring)-1]
+    }
+    return ring
+}
\ No newline at end of file
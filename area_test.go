@@ -0,0 +1,38 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// Planimeter test vectors from GeographicLib on WGS84
+func TestEllipsoidAreaPlanimeter(t *testing.T) {
+    tests := []struct {
+        name      string
+        ring      []Point
+        perimeter float64 // Meters
+        perimTol  float64 // Last printed digit of the reference perimeter
+        area      float64 // Square meters
+    }{
+        {"north polar square", []Point{{89, 0}, {89, 90}, {89, 180}, {89, -90}}, 631819.8745, 1e-4, 24952305678.0},
+        {"south polar square", []Point{{-89, 0}, {-89, 90}, {-89, 180}, {-89, -90}}, 631819.8745, 1e-4, 24952305678.0},
+        {"small diamond", []Point{{0, -1}, {-1, 0}, {0, 1}, {1, 0}}, 627598.2731, 1e-4, 24619419146.0},
+        {"octant", []Point{{90, 0}, {0, 0}, {0, 90}}, 30022685, 1, 63758202715511.0},
+        {"antarctica", []Point{
+            {-63.1, -58}, {-72.9, -74}, {-71.9, -102}, {-74.9, -102}, {-74.3, -131},
+            {-77.5, -163}, {-77.4, 163}, {-71.7, 172}, {-65.9, 140}, {-65.7, 113},
+            {-66.6, 88}, {-66.9, 59}, {-69.8, 25}, {-70.0, -4}, {-71.0, -14},
+            {-77.3, -33}, {-77.9, -46}, {-74.7, -61},
+        }, 16831067.893, 1e-3, 13662703680020.1},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := WGS84.Area(tt.ring) * 1e6; math.Abs(got-tt.area) > 1 {
+                t.Errorf("area = %.1f m², want %.1f", got, tt.area)
+            }
+            if got := WGS84.Perimeter(tt.ring) * 1e3; math.Abs(got-tt.perimeter) > tt.perimTol {
+                t.Errorf("perimeter = %.4f m, want %.4f", got, tt.perimeter)
+            }
+        })
+    }
+}
@@ -0,0 +1,115 @@
+package geoutil
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "sync"
+)
+
+// ItemError records the failure of a single batch item
+type ItemError struct {
+    Index int   // Position of the item in the input slice
+    Err   error // Failure cause
+}
+
+// BatchError aggregates per-item failures of a batch operation
+// Batch functions return it together with the results of all successful items
+type BatchError struct {
+    Total  int         // Number of items in the batch
+    Errors []ItemError // Failed items ordered by index
+}
+
+// Error summarizes the failed items
+func (e *BatchError) Error() string {
+    if len(e.Errors) == 0 {
+        return fmt.Sprintf("batch of %d items: no failures", e.Total)
+    }
+    first := e.Errors[0]
+    return fmt.Sprintf("%d of %d batch items failed (item %d: %v)",
+        len(e.Errors), e.Total, first.Index, first.Err)
+}
+
+// Unwrap exposes item errors to errors.Is and errors.As
+func (e *BatchError) Unwrap() []error {
+    errs := make([]error, len(e.Errors))
+    for i, item := range e.Errors {
+        errs[i] = item.Err
+    }
+    return errs
+}
+
+// Failed reports whether the item at index failed and returns its error
+// index: Position of the item in the input slice
+// Returns: Item error or nil if the item succeeded
+func (e *BatchError) Failed(index int) error {
+    i := sort.Search(len(e.Errors), func(i int) bool { return e.Errors[i].Index >= index })
+    if i < len(e.Errors) && e.Errors[i].Index == index {
+        return e.Errors[i].Err
+    }
+    return nil
+}
+
+// runBatch applies fn to every item using a bounded worker pool
+// All items are processed; a cancelled context fails the remaining items
+// Returns: Results in input order and *BatchError if any item failed
+func runBatch[T, R any](ctx context.Context, items []T, workers int, fn func(context.Context, T) (R, error)) ([]R, error) {
+    type result struct {
+        index int
+        value R
+        err   error
+    }
+
+    if workers > len(items) {
+        workers = len(items)
+    }
+
+    tasks := make(chan int, len(items))
+    results := make(chan result, len(items))
+    var wg sync.WaitGroup
+
+    // Start workers
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range tasks {
+                if err := ctx.Err(); err != nil {
+                    results <- result{index: i, err: err}
+                    continue
+                }
+                value, err := fn(ctx, items[i])
+                results <- result{i, value, err}
+            }
+        }()
+    }
+
+    // Send tasks
+    for i := range items {
+        tasks <- i
+    }
+    close(tasks)
+
+    // Close results when done
+    go func() {
+        wg.Wait()
+        close(results)
+    }()
+
+    // Collect results
+    values := make([]R, len(items))
+    var failed []ItemError
+    for res := range results {
+        if res.err != nil {
+            failed = append(failed, ItemError{res.index, res.err})
+            continue
+        }
+        values[res.index] = res.value
+    }
+
+    if len(failed) == 0 {
+        return values, nil
+    }
+    sort.Slice(failed, func(i, j int) bool { return failed[i].Index < failed[j].Index })
+    return values, &BatchError{Total: len(items), Errors: failed}
+}
\ No newline at end of file
@@ -0,0 +1,212 @@
+package geoutil
+
+import (
+    "context"
+    "errors"
+    "sort"
+    "sync/atomic"
+    "testing"
+)
+
+var errFakeNotFound = errors.New("fake: not found")
+
+// fakeGeocoder reverse-geocodes points with non-negative latitude and fails the rest
+type fakeGeocoder struct {
+    calls  atomic.Int64
+    before func(ctx context.Context, p Point) // Optional hook run before each lookup
+}
+
+var _ GeocoderContext = (*fakeGeocoder)(nil)
+
+func (f *fakeGeocoder) Geocode(address string) (Point, error) {
+    return f.GeocodeContext(context.Background(), address)
+}
+
+func (f *fakeGeocoder) ReverseGeocode(p Point) (Location, error) {
+    return f.ReverseGeocodeContext(context.Background(), p)
+}
+
+func (f *fakeGeocoder) BatchGeocode(addresses []string) ([]Point, error) {
+    return f.BatchGeocodeContext(context.Background(), addresses)
+}
+
+func (f *fakeGeocoder) BatchReverseGeocode(points []Point) ([]Location, error) {
+    return f.BatchReverseGeocodeContext(context.Background(), points)
+}
+
+func (f *fakeGeocoder) GeocodeContext(ctx context.Context, address string) (Point, error) {
+    if address == "" {
+        return Point{}, errFakeNotFound
+    }
+    return Point{Lat: float64(len(address))}, ctx.Err()
+}
+
+func (f *fakeGeocoder) ReverseGeocodeContext(ctx context.Context, p Point) (Location, error) {
+    f.calls.Add(1)
+    if f.before != nil {
+        f.before(ctx, p)
+    }
+    if err := ctx.Err(); err != nil {
+        return Location{}, err
+    }
+    if p.Lat < 0 {
+        return Location{}, &CoordinateError{Point: p, Reason: "fake: southern hemisphere"}
+    }
+    return Location{City: "fake", Lat: p.Lat, Lon: p.Lon}, nil
+}
+
+func (f *fakeGeocoder) BatchGeocodeContext(ctx context.Context, addresses []string) ([]Point, error) {
+    return runBatch(ctx, addresses, 4, f.GeocodeContext)
+}
+
+func (f *fakeGeocoder) BatchReverseGeocodeContext(ctx context.Context, points []Point) ([]Location, error) {
+    return runBatch(ctx, points, 4, f.ReverseGeocodeContext)
+}
+
+// fakeElevation returns ten times the latitude and fails for longitudes above 100
+type fakeElevation struct{}
+
+var _ ElevationProviderContext = fakeElevation{}
+
+func (f fakeElevation) GetElevation(p Point) (int, error) {
+    return f.GetElevationContext(context.Background(), p)
+}
+
+func (f fakeElevation) BatchGetElevation(points []Point) ([]int, error) {
+    return f.BatchGetElevationContext(context.Background(), points)
+}
+
+func (fakeElevation) GetElevationContext(ctx context.Context, p Point) (int, error) {
+    if err := ctx.Err(); err != nil {
+        return 0, err
+    }
+    if p.Lon > 100 {
+        return 0, errFakeNotFound
+    }
+    return int(p.Lat * 10), nil
+}
+
+func (f fakeElevation) BatchGetElevationContext(ctx context.Context, points []Point) ([]int, error) {
+    return runBatch(ctx, points, 4, f.GetElevationContext)
+}
+
+func TestBatchPartialResults(t *testing.T) {
+    points := make([]Point, 50)
+    wantFailed := map[int]bool{}
+    for i := range points {
+        points[i] = Point{Lat: float64(i), Lon: 10}
+        switch {
+        case i%7 == 3:
+            points[i].Lat = -1 // Geocoder failure
+            wantFailed[i] = true
+        case i%11 == 5:
+            points[i].Lon = 150 // Elevation failure
+            wantFailed[i] = true
+        }
+    }
+
+    locs, err := BatchFullLocationContext(context.Background(), points, &fakeGeocoder{}, fakeElevation{})
+    var batchErr *BatchError
+    if !errors.As(err, &batchErr) {
+        t.Fatalf("error = %v, want *BatchError", err)
+    }
+    if batchErr.Total != len(points) || len(batchErr.Errors) != len(wantFailed) {
+        t.Fatalf("BatchError total %d, %d failures; want %d, %d", batchErr.Total, len(batchErr.Errors), len(points), len(wantFailed))
+    }
+    if !sort.SliceIsSorted(batchErr.Errors, func(i, j int) bool { return batchErr.Errors[i].Index < batchErr.Errors[j].Index }) {
+        t.Errorf("failed indexes not sorted: %v", batchErr.Errors)
+    }
+    if len(locs) != len(points) {
+        t.Fatalf("got %d results, want %d", len(locs), len(points))
+    }
+    for i, loc := range locs {
+        if wantFailed[i] {
+            if batchErr.Failed(i) == nil {
+                t.Errorf("Failed(%d) = nil, want an error", i)
+            }
+            if loc != (Location{}) {
+                t.Errorf("failed item %d = %+v, want zero value", i, loc)
+            }
+            continue
+        }
+        if err := batchErr.Failed(i); err != nil {
+            t.Errorf("Failed(%d) = %v, want nil", i, err)
+        }
+        if loc.City != "fake" || loc.Elevation != int(points[i].Lat*10) {
+            t.Errorf("item %d = %+v, want geocoded with elevation", i, loc)
+        }
+    }
+    if batchErr.Failed(-1) != nil || batchErr.Failed(len(points)) != nil {
+        t.Error("Failed reports indexes outside the batch")
+    }
+
+    // Item errors are reachable through Unwrap() []error
+    if !errors.Is(err, errFakeNotFound) {
+        t.Error("errors.Is(err, errFakeNotFound) = false")
+    }
+    if !errors.Is(err, ErrInvalidCoordinate) {
+        t.Error("errors.Is(err, ErrInvalidCoordinate) = false")
+    }
+    var coordErr *CoordinateError
+    if !errors.As(err, &coordErr) || coordErr.Point.Lat != -1 {
+        t.Errorf("errors.As(*CoordinateError) = %v", coordErr)
+    }
+}
+
+func TestBatchNoFailures(t *testing.T) {
+    elevs, err := fakeElevation{}.BatchGetElevation([]Point{{1, 0}, {2, 0}, {3, 0}})
+    if err != nil {
+        t.Fatalf("error = %v, want nil", err)
+    }
+    if len(elevs) != 3 || elevs[0] != 10 || elevs[2] != 30 {
+        t.Errorf("elevations = %v, want [10 20 30]", elevs)
+    }
+}
+
+func TestBatchCancelled(t *testing.T) {
+    points := make([]Point, 200)
+    for i := range points {
+        points[i] = Point{Lat: float64(i % 90), Lon: 10}
+    }
+
+    // A context cancelled up front fails every item without calling the geocoder
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    g := &fakeGeocoder{}
+    _, err := g.BatchReverseGeocodeContext(ctx, points)
+    var batchErr *BatchError
+    if !errors.As(err, &batchErr) || len(batchErr.Errors) != len(points) {
+        t.Fatalf("error = %v, want all %d items failed", err, len(points))
+    }
+    if !errors.Is(err, context.Canceled) || !errors.Is(batchErr.Failed(len(points)-1), context.Canceled) {
+        t.Errorf("error = %v, want context.Canceled", err)
+    }
+    if n := g.calls.Load(); n != 0 {
+        t.Errorf("geocoder called %d times after cancellation", n)
+    }
+
+    // Cancelling mid-batch keeps finished items and fails the rest
+    ctx, cancel = context.WithCancel(context.Background())
+    defer cancel()
+    g = &fakeGeocoder{before: func(_ context.Context, p Point) {
+        if p.Lat == 10 {
+            cancel()
+        }
+    }}
+    locs, err := g.BatchReverseGeocodeContext(ctx, points)
+    if !errors.As(err, &batchErr) {
+        t.Fatalf("error = %v, want *BatchError", err)
+    }
+    if len(batchErr.Errors) < len(points)/2 {
+        t.Errorf("%d items failed, want the remaining items to fail", len(batchErr.Errors))
+    }
+    for i, loc := range locs {
+        if err := batchErr.Failed(i); err != nil {
+            if !errors.Is(err, context.Canceled) {
+                t.Errorf("item %d error = %v, want context.Canceled", i, err)
+            }
+        } else if loc.City != "fake" {
+            t.Errorf("item %d = %+v, want a result", i, loc)
+        }
+    }
+}
@@ -0,0 +1,325 @@
+package geoutil
+
+import (
+    "context"
+    "math"
+    "sort"
+)
+
+// BBox is a latitude/longitude bounding box
+// A box crossing the antimeridian has MinLon > MaxLon, e.g. 170 to -170 is 20 degrees wide
+type BBox struct {
+    MinLat, MinLon float64 // South-west corner
+    MaxLat, MaxLon float64 // North-east corner
+}
+
+var _ Container = BBox{}
+
+// bboxPad widens computed boxes so points exactly at a distance pass the filter
+const bboxPad = 1e-9
+
+// EmptyBBox returns a box that contains nothing and is the identity for Union
+func EmptyBBox() BBox {
+    return BBox{MinLat: math.Inf(1), MinLon: 0, MaxLat: math.Inf(-1), MaxLon: 0}
+}
+
+// NewBBox computes the smallest box containing the points
+// Longitudes wrap, so points on both sides of ±180° give a box crossing the antimeridian
+// points: Points to cover
+// Returns: Bounding box, empty for no points
+func NewBBox(points []Point) BBox {
+    if len(points) == 0 {
+        return EmptyBBox()
+    }
+    b := BBox{MinLat: math.Inf(1), MaxLat: math.Inf(-1)}
+    lons := make([]float64, len(points))
+    for i, p := range points {
+        b.MinLat = math.Min(b.MinLat, p.Lat)
+        b.MaxLat = math.Max(b.MaxLat, p.Lat)
+        lons[i] = angNormalize(p.Lon)
+    }
+    sort.Float64s(lons)
+
+    // The box is the complement of the widest gap between neighboring longitudes
+    b.MinLon, b.MaxLon = lons[0], lons[len(lons)-1]
+    gap := lons[0] + 360 - lons[len(lons)-1]
+    for i := 0; i+1 < len(lons); i++ {
+        if d := lons[i+1] - lons[i]; d > gap {
+            gap = d
+            b.MinLon, b.MaxLon = lons[i+1], lons[i]
+        }
+    }
+    return b
+}
+
+// BBoxAround computes a box containing every point within radius of p on EarthSphere
+// Use it to skip DistanceHaversine for points that cannot be within radius
+// p: Center
+// radius: Radius in kilometers
+// Returns: Bounding box, covering all longitudes if the circle reaches a pole
+func BBoxAround(p Point, radius float64) BBox {
+    return BBox{MinLat: p.Lat, MinLon: p.Lon, MaxLat: p.Lat, MaxLon: p.Lon}.Expand(radius)
+}
+
+// BBox computes the bounding box of the outer ring as interpreted by Mode
+// Planar polygons never cross the antimeridian; other modes give the smallest box
+// Returns: Box containing every point the polygon contains
+func (pg Polygon) BBox() BBox {
+    if len(pg.Outer) == 0 {
+        return EmptyBBox()
+    }
+    r := ringBounds(pg.Outer, pg.Mode)
+    b := BBox{MinLat: r.minLat, MinLon: r.minLon, MaxLat: r.maxLat, MaxLon: r.maxLon}
+    if pg.Mode == ContainmentPlanar || len(pg.Outer) < 3 {
+        return b
+    }
+    u := unwrapRing(pg.Outer)
+    if u.pole == 0 && u.maxLon-u.minLon < 360 {
+        b.MinLon, b.MaxLon = angNormalize(u.minLon), angNormalize(u.maxLon)
+    }
+    return b
+}
+
+// BBox computes the box covering all polygons
+// Returns: Bounding box, empty for no polygons
+func (mp MultiPolygon) BBox() BBox {
+    b := EmptyBBox()
+    for _, pg := range mp {
+        b = b.Union(pg.BBox())
+    }
+    return b
+}
+
+// IsEmpty reports whether the box contains no points
+func (b BBox) IsEmpty() bool {
+    return !(b.MinLat <= b.MaxLat)
+}
+
+// CrossesAntimeridian reports whether the box spans ±180° longitude
+func (b BBox) CrossesAntimeridian() bool {
+    return !b.IsEmpty() && b.MinLon > b.MaxLon
+}
+
+// Center returns the middle of the box, taking antimeridian crossing into account
+func (b BBox) Center() Point {
+    return Point{
+        Lat: (b.MinLat + b.MaxLat) / 2,
+        Lon: angNormalize(b.MinLon + b.lonWidth()/2),
+    }
+}
+
+// Contains determines if a point lies in the box, edges included
+// p: Point to check
+// Returns: true if the point is inside the box
+func (b BBox) Contains(p Point) bool {
+    if b.IsEmpty() || p.Lat < b.MinLat || p.Lat > b.MaxLat {
+        return false
+    }
+    if b.MinLon <= b.MaxLon && p.Lon >= b.MinLon && p.Lon <= b.MaxLon {
+        return true
+    }
+    lon := angNormalize(p.Lon)
+    if b.lonContains(lon) {
+        return true
+    }
+    // -180 and 180 are the same meridian
+    return math.Abs(lon) == 180 && b.lonContains(-lon)
+}
+
+// ContainsBBox determines if o lies entirely within the box
+// o: Box to check
+// Returns: true if o is inside the box; an empty o is inside every box
+func (b BBox) ContainsBBox(o BBox) bool {
+    if o.IsEmpty() {
+        return true
+    }
+    if b.IsEmpty() || o.MinLat < b.MinLat || o.MaxLat > b.MaxLat {
+        return false
+    }
+    if b.lonWidth() >= 360 {
+        return true
+    }
+    if o.lonWidth() >= 360 {
+        return false
+    }
+    // Both edges of o lie within b and o does not wrap past b's western edge
+    start, end := lonOffset(b.MinLon, o.MinLon), lonOffset(b.MinLon, o.MaxLon)
+    return start <= end && end <= b.lonWidth()
+}
+
+// Intersects determines if the boxes share at least one point
+// o: Other box
+// Returns: true if the boxes overlap or touch
+func (b BBox) Intersects(o BBox) bool {
+    return !b.Intersection(o).IsEmpty()
+}
+
+// Intersection computes the box shared by b and o
+// Two boxes crossing each other's ends around the globe overlap in two pieces;
+// the result then covers both pieces
+// o: Other box
+// Returns: Shared box, empty if the boxes do not overlap
+func (b BBox) Intersection(o BBox) BBox {
+    if b.IsEmpty() || o.IsEmpty() {
+        return EmptyBBox()
+    }
+    lat0, lat1 := math.Max(b.MinLat, o.MinLat), math.Min(b.MaxLat, o.MaxLat)
+    if lat0 > lat1 {
+        return EmptyBBox()
+    }
+    wb, wo := b.lonWidth(), o.lonWidth()
+    out := BBox{MinLat: lat0, MaxLat: lat1}
+    switch {
+    case wb >= 360:
+        out.MinLon, out.MaxLon = o.MinLon, o.MaxLon
+    case wo >= 360:
+        out.MinLon, out.MaxLon = b.MinLon, b.MaxLon
+    default:
+        dOther := lonOffset(b.MinLon, o.MinLon) // Where o starts within b
+        dSelf := lonOffset(o.MinLon, b.MinLon)  // Where b starts within o
+        inB, inO := dOther <= wb, dSelf <= wo
+        switch {
+        case inB && inO && dOther != 0:
+            // Two pieces: the narrower box covers both
+            out.MinLon, out.MaxLon = b.MinLon, b.MaxLon
+            if wo < wb {
+                out.MinLon, out.MaxLon = o.MinLon, o.MaxLon
+            }
+        case inB:
+            out.MinLon, out.MaxLon = o.MinLon, o.MaxLon
+            if wo > wb-dOther {
+                out.MaxLon = b.MaxLon
+            }
+        case inO:
+            out.MinLon, out.MaxLon = b.MinLon, b.MaxLon
+            if wb > wo-dSelf {
+                out.MaxLon = o.MaxLon
+            }
+        default:
+            return EmptyBBox()
+        }
+    }
+    return out
+}
+
+// Union computes the smallest box containing both boxes
+// o: Other box
+// Returns: Covering box
+func (b BBox) Union(o BBox) BBox {
+    if b.IsEmpty() {
+        return o
+    }
+    if o.IsEmpty() {
+        return b
+    }
+    lat0, lat1 := math.Min(b.MinLat, o.MinLat), math.Max(b.MaxLat, o.MaxLat)
+    wb, wo := b.lonWidth(), o.lonWidth()
+    if wb >= 360 || wo >= 360 {
+        return lonBox(lat0, lat1, -180, 360)
+    }
+    // Either start at b and reach the end of o, or the other way around
+    fromB := lonOffset(b.MinLon, o.MinLon) + wo
+    fromO := lonOffset(o.MinLon, b.MinLon) + wb
+    if math.Min(math.Max(wb, fromB), math.Max(wo, fromO)) >= 360 {
+        return lonBox(lat0, lat1, -180, 360)
+    }
+    out := BBox{MinLat: lat0, MaxLat: lat1}
+    if math.Max(wb, fromB) <= math.Max(wo, fromO) {
+        out.MinLon, out.MaxLon = b.MinLon, b.MaxLon
+        if fromB > wb {
+            out.MaxLon = o.MaxLon
+        }
+    } else {
+        out.MinLon, out.MaxLon = o.MinLon, o.MaxLon
+        if fromO > wo {
+            out.MaxLon = b.MaxLon
+        }
+    }
+    return out
+}
+
+// Extend computes the smallest box containing the box and a point
+// p: Point to include
+// Returns: Covering box
+func (b BBox) Extend(p Point) BBox {
+    return b.Union(BBox{MinLat: p.Lat, MinLon: p.Lon, MaxLat: p.Lat, MaxLon: p.Lon})
+}
+
+// Expand grows the box to contain every point within distance of it on EarthSphere
+// distance: Distance in kilometers (non-negative)
+// Returns: Expanded box, covering all longitudes if it reaches a pole
+func (b BBox) Expand(distance float64) BBox {
+    if b.IsEmpty() || distance <= 0 {
+        return b
+    }
+    δ := distance / EarthSphere.Radius
+    dLat := δ*radToDeg + bboxPad
+    lat0, lat1 := b.MinLat-dLat, b.MaxLat+dLat
+    if lat0 <= -90 || lat1 >= 90 || δ >= math.Pi/2 {
+        return lonBox(math.Max(-90, lat0), math.Min(90, lat1), -180, 360)
+    }
+
+    // A circle of angular radius δ centered at latitude φ spans asin(sin δ / cos φ)
+    // in longitude, which grows toward the poles
+    φ := math.Max(math.Abs(b.MinLat), math.Abs(b.MaxLat)) * degToRad
+    dLon := math.Asin(math.Min(1, math.Sin(δ)/math.Cos(φ)))*radToDeg + bboxPad
+    width := b.lonWidth() + 2*dLon
+    if width >= 360 {
+        return lonBox(lat0, lat1, -180, 360)
+    }
+    return lonBox(lat0, lat1, b.MinLon-dLon, width)
+}
+
+// lonWidth returns the longitude span of the box in degrees
+func (b BBox) lonWidth() float64 {
+    if b.MaxLon-b.MinLon >= 360 {
+        return 360
+    }
+    return lonOffset(b.MinLon, b.MaxLon)
+}
+
+// lonContains reports whether a normalized longitude is within the box's range
+func (b BBox) lonContains(lon float64) bool {
+    return lonOffset(b.MinLon, lon) <= b.lonWidth()
+}
+
+// lonOffset returns how far east lon lies from start, in [0, 360)
+func lonOffset(start, lon float64) float64 {
+    d := math.Mod(lon-start, 360)
+    if d < 0 {
+        d += 360
+    }
+    return d
+}
+
+// lonBox builds a box from a latitude range and a longitude range given by its
+// western edge and width
+func lonBox(lat0, lat1, start, width float64) BBox {
+    if width >= 360 {
+        return BBox{MinLat: lat0, MinLon: -180, MaxLat: lat1, MaxLon: 180}
+    }
+    return BBox{MinLat: lat0, MinLon: angNormalize(start), MaxLat: lat1, MaxLon: angNormalize(start + width)}
+}
+
+// FilterPointsWithinRadius selects points within radius of a center concurrently
+// Points outside BBoxAround(center, radius) are rejected without computing distances
+// points: Slice of points to filter
+// center: Center point
+// radius: Radius in kilometers, measured with DistanceHaversine
+// Returns: Points within radius, in input order
+func FilterPointsWithinRadius(points []Point, center Point, radius float64) []Point {
+    box := BBoxAround(center, radius)
+    inside := make([]bool, len(points))
+    opts := MatrixOptions{ChunkRows: 1000}.withDefaults(len(points))
+    forEachRow(context.Background(), len(points), opts, func(i int) {
+        inside[i] = box.Contains(points[i]) && DistanceHaversine(center, points[i]) <= radius
+    })
+
+    filtered := make([]Point, 0, len(points))
+    for i, ok := range inside {
+        if ok {
+            filtered = append(filtered, points[i])
+        }
+    }
+    return filtered
+}
\ No newline at end of file
@@ -0,0 +1,204 @@
+package geoutil
+
+import (
+    "math"
+    "math/rand"
+    "testing"
+)
+
+// bboxEqual compares boxes with a tolerance, treating all empty boxes as equal
+func bboxEqual(a, b BBox, tol float64) bool {
+    if a.IsEmpty() || b.IsEmpty() {
+        return a.IsEmpty() == b.IsEmpty()
+    }
+    return math.Abs(a.MinLat-b.MinLat) <= tol && math.Abs(a.MaxLat-b.MaxLat) <= tol &&
+        math.Abs(a.MinLon-b.MinLon) <= tol && math.Abs(a.MaxLon-b.MaxLon) <= tol
+}
+
+var (
+    fullWidth  = BBox{-10, -180, 10, 180}
+    fijiBox    = BBox{0, 170, 10, -170} // 20° wide across ±180°
+    almostFull = BBox{0, -170, 10, 170} // 340° wide, not crossing
+)
+
+func TestBBoxIntersection(t *testing.T) {
+    tests := []struct {
+        name string
+        a, b BBox
+        want BBox
+    }{
+        {"overlap", BBox{0, 0, 10, 10}, BBox{5, 5, 15, 15}, BBox{5, 5, 10, 10}},
+        {"nested", BBox{0, 0, 10, 10}, BBox{2, 3, 4, 5}, BBox{2, 3, 4, 5}},
+        {"touching edge", BBox{0, 0, 10, 10}, BBox{0, 10, 10, 20}, BBox{0, 10, 10, 10}},
+        {"disjoint latitude", BBox{0, 0, 10, 10}, BBox{11, 0, 20, 10}, EmptyBBox()},
+        {"disjoint longitude", BBox{0, 0, 10, 10}, BBox{0, 11, 10, 20}, EmptyBBox()},
+        {"empty", BBox{0, 0, 10, 10}, EmptyBBox(), EmptyBBox()},
+        {"inside crossing box", fijiBox, BBox{0, 175, 10, -175}, BBox{0, 175, 10, -175}},
+        {"east of antimeridian", fijiBox, BBox{0, -175, 10, 0}, BBox{0, -175, 10, -170}},
+        {"west of antimeridian", fijiBox, BBox{0, 100, 10, 175}, BBox{0, 170, 10, 175}},
+        {"both crossing", fijiBox, BBox{5, 175, 20, -160}, BBox{5, 175, 10, -170}},
+        {"crossing vs greenwich", fijiBox, BBox{0, -10, 10, 10}, EmptyBBox()},
+        {"full width", fullWidth, fijiBox, BBox{0, 170, 10, -170}},
+        {"full width reversed", fijiBox, fullWidth, BBox{0, 170, 10, -170}},
+        // Overlaps in [160, 170] and [-170, -160]; the narrower box covers both
+        {"two pieces", almostFull, BBox{0, 160, 10, -160}, BBox{0, 160, 10, -160}},
+        {"two pieces reversed", BBox{0, 160, 10, -160}, almostFull, BBox{0, 160, 10, -160}},
+        {"two pieces narrower first", BBox{0, 10, 10, -10}, BBox{0, -20, 10, 20}, BBox{0, -20, 10, 20}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := tt.a.Intersection(tt.b)
+            if !bboxEqual(got, tt.want, 0) {
+                t.Errorf("Intersection = %+v, want %+v", got, tt.want)
+            }
+            if got, want := tt.a.Intersects(tt.b), !tt.want.IsEmpty(); got != want {
+                t.Errorf("Intersects = %v, want %v", got, want)
+            }
+        })
+    }
+}
+
+func TestBBoxUnion(t *testing.T) {
+    tests := []struct {
+        name string
+        a, b BBox
+        want BBox
+    }{
+        {"separate", BBox{0, 0, 10, 10}, BBox{5, 20, 15, 30}, BBox{0, 0, 15, 30}},
+        {"nested", BBox{0, 0, 10, 10}, BBox{2, 3, 4, 5}, BBox{0, 0, 10, 10}},
+        {"empty", EmptyBBox(), BBox{1, 2, 3, 4}, BBox{1, 2, 3, 4}},
+        {"empty other", BBox{1, 2, 3, 4}, EmptyBBox(), BBox{1, 2, 3, 4}},
+        {"shorter across antimeridian", BBox{0, 170, 10, 175}, BBox{0, -175, 10, -170}, BBox{0, 170, 10, -170}},
+        {"shorter across greenwich", BBox{0, -20, 10, -10}, BBox{0, 10, 10, 20}, BBox{0, -20, 10, 20}},
+        {"crossing with east", fijiBox, BBox{-5, -160, 5, -150}, BBox{-5, 170, 10, -150}},
+        {"full width", fullWidth, fijiBox, BBox{-10, -180, 10, 180}},
+        {"wraps all the way", almostFull, BBox{0, 160, 10, -160}, BBox{0, -180, 10, 180}},
+        {"half globe", BBox{0, 0, 1, 1}, BBox{0, 179, 1, 180}, BBox{0, 0, 1, 180}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := tt.a.Union(tt.b)
+            if !bboxEqual(got, tt.want, 0) {
+                t.Errorf("Union = %+v, want %+v", got, tt.want)
+            }
+            if !got.ContainsBBox(tt.a) || !got.ContainsBBox(tt.b) {
+                t.Errorf("Union %+v does not contain both inputs", got)
+            }
+        })
+    }
+}
+
+func TestBBoxContainsBBox(t *testing.T) {
+    tests := []struct {
+        name string
+        a, b BBox
+        want bool
+    }{
+        {"nested", BBox{0, 0, 10, 10}, BBox{2, 3, 4, 5}, true},
+        {"itself", BBox{0, 0, 10, 10}, BBox{0, 0, 10, 10}, true},
+        {"latitude outside", BBox{0, 0, 10, 10}, BBox{-1, 3, 4, 5}, false},
+        {"longitude outside", BBox{0, 0, 10, 10}, BBox{2, 3, 4, 11}, false},
+        {"empty inside any", BBox{0, 0, 10, 10}, EmptyBBox(), true},
+        {"nothing inside empty", EmptyBBox(), BBox{0, 0, 0, 0}, false},
+        {"inside crossing box", fijiBox, BBox{2, 175, 8, -175}, true},
+        {"east part", fijiBox, BBox{2, -175, 8, -170}, true},
+        {"overhangs west", fijiBox, BBox{2, 160, 8, 175}, false},
+        // -175 to 175 is 350° wide, the long way round
+        {"reversed edges", fijiBox, BBox{2, -175, 8, 175}, false},
+        {"plain box in crossing box", fijiBox, BBox{2, 0, 8, 10}, false},
+        {"crossing box in full width", fullWidth, BBox{0, 170, 10, -170}, true},
+        {"full width in crossing box", fijiBox, BBox{0, -180, 10, 180}, false},
+        {"full width in full width", fullWidth, BBox{0, -180, 10, 180}, true},
+        {"crossing box in plain box", almostFull, BBox{2, 160, 8, -160}, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := tt.a.ContainsBBox(tt.b); got != tt.want {
+                t.Errorf("%+v.ContainsBBox(%+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestBBoxExpand(t *testing.T) {
+    oneDegree := EarthSphere.Radius * degToRad
+    // Longitude half-width of a 1° circle centered at 60°
+    at60 := math.Asin(math.Sin(degToRad)/math.Cos(60*degToRad)) * radToDeg
+    at10 := math.Asin(math.Sin(degToRad)/math.Cos(10*degToRad)) * radToDeg
+    tests := []struct {
+        name     string
+        b        BBox
+        distance float64
+        want     BBox
+    }{
+        {"equator", BBox{0, 0, 0, 0}, oneDegree, BBox{-1, -1, 1, 1}},
+        {"box", BBox{10, 20, 10, 30}, 0, BBox{10, 20, 10, 30}},
+        {"negative distance", BBox{10, 20, 10, 30}, -5, BBox{10, 20, 10, 30}},
+        {"empty", EmptyBBox(), oneDegree, EmptyBBox()},
+        {"widens toward poles", BBox{59, 10, 60, 10}, oneDegree, BBox{58, 10 - at60, 61, 10 + at60}},
+        {"southern uses far edge", BBox{-60, 10, -59, 10}, oneDegree, BBox{-61, 10 - at60, -58, 10 + at60}},
+        {"crosses antimeridian", BBox{0, 179.5, 0, 179.5}, oneDegree, BBox{-1, 178.5, 1, -179.5}},
+        {"crossing box grows", fijiBox, oneDegree, BBox{-1, 170 - at10, 11, -170 + at10}},
+        {"north pole", BBox{89.5, 0, 89.5, 0}, oneDegree, BBox{88.5, -180, 90, 180}},
+        {"south pole", BBox{-89.5, 45, -89.5, 45}, oneDegree, BBox{-90, -180, -88.5, 180}},
+        {"nearly full width", BBox{0, -179, 1, 179}, oneDegree, BBox{-1, -180, 2, 180}},
+        {"quarter of the globe", BBox{0, 0, 0, 0}, 90 * oneDegree, BBox{-90, -180, 90, 180}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            // Expand pads by a nanodegree
+            if got := tt.b.Expand(tt.distance); !bboxEqual(got, tt.want, 1e-8) {
+                t.Errorf("Expand = %+v, want %+v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestBBoxAroundCoversCircle(t *testing.T) {
+    rng := rand.New(rand.NewSource(6))
+    centers := []Point{{0, 0}, {45, 179.9}, {-45, -179.9}, {89, 10}, {-88, -30}, {70, 0}}
+    for _, c := range centers {
+        for _, radius := range []float64{1, 100, 500} {
+            box := BBoxAround(c, radius)
+            for i := 0; i < 200; i++ {
+                p := EarthSphere.Destination(c, 360*rng.Float64(), radius*rng.Float64())
+                if !box.Contains(p) {
+                    t.Fatalf("BBoxAround(%v, %v) = %+v misses %v", c, radius, box, p)
+                }
+            }
+        }
+    }
+}
+
+func TestFilterPointsWithinRadius(t *testing.T) {
+    rng := rand.New(rand.NewSource(7))
+    points := randomPoints(rng, Point{0, 0}, 5000, 89.9)
+    points = append(points, randomPoints(rng, Point{60, 179}, 2000, 5)...)
+    points = append(points, randomPoints(rng, Point{-87, 0}, 2000, 3)...)
+    for i := range points {
+        points[i].Lon = angNormalize(points[i].Lon)
+    }
+
+    centers := []Point{{0, 0}, {60, 179.5}, {60, -179.5}, {-89, 120}, {-87, 0}, {89.99, 0}}
+    for _, c := range centers {
+        for _, radius := range []float64{10, 200, 1500} {
+            got := FilterPointsWithinRadius(points, c, radius)
+            var want []Point
+            for _, p := range points {
+                if DistanceHaversine(c, p) <= radius {
+                    want = append(want, p)
+                }
+            }
+            if len(got) != len(want) {
+                t.Errorf("FilterPointsWithinRadius(%v, %v): got %d points, want %d", c, radius, len(got), len(want))
+                continue
+            }
+            for i := range got {
+                if got[i] != want[i] {
+                    t.Errorf("FilterPointsWithinRadius(%v, %v)[%d] = %v, want %v", c, radius, i, got[i], want[i])
+                    break
+                }
+            }
+        }
+    }
+}
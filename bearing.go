@@ -0,0 +1,111 @@
+package geoutil
+
+import "math"
+
+// Degree/radian conversion factors
+const (
+    degToRad = math.Pi / 180
+    radToDeg = 180 / math.Pi
+)
+
+// InitialBearing calculates the initial great-circle bearing from p1 to p2
+// p1, p2: Geographic points
+// Returns: Bearing in degrees clockwise from north [0, 360)
+func InitialBearing(p1, p2 Point) float64 {
+    φ1 := p1.Lat * degToRad
+    φ2 := p2.Lat * degToRad
+    Δλ := (p2.Lon - p1.Lon) * degToRad
+
+    y := math.Sin(Δλ) * math.Cos(φ2)
+    x := math.Cos(φ1)*math.Sin(φ2) - math.Sin(φ1)*math.Cos(φ2)*math.Cos(Δλ)
+    return bearing360(math.Atan2(y, x) * radToDeg)
+}
+
+// FinalBearing calculates the great-circle bearing on arrival at p2
+// p1, p2: Geographic points
+// Returns: Bearing in degrees clockwise from north [0, 360)
+func FinalBearing(p1, p2 Point) float64 {
+    return bearing360(InitialBearing(p2, p1) + 180)
+}
+
+// Destination calculates the point reached from start along a great circle on EarthSphere
+// p: Start point
+// bearing: Initial bearing in degrees clockwise from north
+// distance: Distance in kilometers
+// Returns: Destination point
+func Destination(p Point, bearing, distance float64) Point {
+    return EarthSphere.Destination(p, bearing, distance)
+}
+
+// Destination calculates the point reached from start along a great circle
+// p: Start point
+// bearing: Initial bearing in degrees clockwise from north
+// distance: Distance in kilometers
+// Returns: Destination point
+func (s Sphere) Destination(p Point, bearing, distance float64) Point {
+    δ := distance / s.Radius
+    θ := bearing * degToRad
+    φ1 := p.Lat * degToRad
+    λ1 := p.Lon * degToRad
+
+    sinφ2 := math.Sin(φ1)*math.Cos(δ) + math.Cos(φ1)*math.Sin(δ)*math.Cos(θ)
+    φ2 := math.Asin(math.Max(-1, math.Min(1, sinφ2)))
+    y := math.Sin(θ) * math.Sin(δ) * math.Cos(φ1)
+    x := math.Cos(δ) - math.Sin(φ1)*sinφ2
+    λ2 := λ1 + math.Atan2(y, x)
+
+    return Point{Lat: φ2 * radToDeg, Lon: angNormalize(λ2 * radToDeg)}
+}
+
+// Midpoint calculates the point halfway along the great circle between p1 and p2
+// p1, p2: Geographic points
+// Returns: Midpoint
+func Midpoint(p1, p2 Point) Point {
+    φ1 := p1.Lat * degToRad
+    φ2 := p2.Lat * degToRad
+    λ1 := p1.Lon * degToRad
+    Δλ := (p2.Lon - p1.Lon) * degToRad
+
+    bx := math.Cos(φ2) * math.Cos(Δλ)
+    by := math.Cos(φ2) * math.Sin(Δλ)
+    φm := math.Atan2(math.Sin(φ1)+math.Sin(φ2), math.Hypot(math.Cos(φ1)+bx, by))
+    λm := λ1 + math.Atan2(by, math.Cos(φ1)+bx)
+
+    return Point{Lat: φm * radToDeg, Lon: angNormalize(λm * radToDeg)}
+}
+
+// IntermediatePoint calculates the point at a fraction of the great circle from p1 to p2
+// p1, p2: Geographic points (must not be antipodal)
+// fraction: 0 returns p1, 1 returns p2
+// Returns: Intermediate point
+func IntermediatePoint(p1, p2 Point, fraction float64) Point {
+    φ1 := p1.Lat * degToRad
+    φ2 := p2.Lat * degToRad
+    λ1 := p1.Lon * degToRad
+    λ2 := p2.Lon * degToRad
+
+    δ := DistanceHaversine(p1, p2) / EarthSphere.Radius
+    if δ == 0 {
+        return p1
+    }
+    a := math.Sin((1-fraction)*δ) / math.Sin(δ)
+    b := math.Sin(fraction*δ) / math.Sin(δ)
+
+    x := a*math.Cos(φ1)*math.Cos(λ1) + b*math.Cos(φ2)*math.Cos(λ2)
+    y := a*math.Cos(φ1)*math.Sin(λ1) + b*math.Cos(φ2)*math.Sin(λ2)
+    z := a*math.Sin(φ1) + b*math.Sin(φ2)
+
+    return Point{
+        Lat: math.Atan2(z, math.Hypot(x, y)) * radToDeg,
+        Lon: math.Atan2(y, x) * radToDeg,
+    }
+}
+
+// DestinationGeodesic calculates the point reached along a geodesic on WGS84
+// p: Start point
+// bearing: Initial azimuth in degrees clockwise from north
+// distance: Distance in kilometers
+// Returns: Destination point and final azimuth in degrees [0, 360)
+func DestinationGeodesic(p Point, bearing, distance float64) (Point, float64) {
+    return WGS84.Direct(p, bearing, distance)
+}
\ No newline at end of file
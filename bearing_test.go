@@ -0,0 +1,156 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// arcSecond is the rounding of worked examples given in degrees, minutes and seconds
+const arcSecond = 1.0 / 3600
+
+// Points from the worked examples of Chris Veness, "Calculate distance, bearing and
+// more between Latitude/Longitude points" (movable-type.co.uk), which uses R = 6371 km
+var (
+    landsEnd     = Point{dms(50, 3, 59), -dms(5, 42, 53)}
+    johnOGroats  = Point{dms(58, 38, 38), -dms(3, 4, 12)}
+    cambridge    = Point{52.205, 0.119}
+    paris        = Point{48.857, 2.351}
+    kinderScout  = Point{dms(53, 19, 14), -dms(1, 43, 47)}
+    greenwichObs = Point{51.47788, -0.00147}
+)
+
+func TestBearings(t *testing.T) {
+    tests := []struct {
+        name      string
+        p1, p2    Point
+        distance  float64 // Kilometers, rounded to 0.1
+        initial   float64
+        final     float64
+        tolerance float64 // Degrees
+    }{
+        {"Land's End to John o' Groats", landsEnd, johnOGroats, 968.9, dms(9, 7, 11), dms(11, 16, 31), arcSecond / 2},
+        {"Cambridge to Paris", cambridge, paris, 404.3, 156.2, 157.9, 0.05},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := DistanceHaversine(tt.p1, tt.p2); math.Abs(got-tt.distance) > 0.05 {
+                t.Errorf("DistanceHaversine = %v, want %v", got, tt.distance)
+            }
+            if got := InitialBearing(tt.p1, tt.p2); math.Abs(got-tt.initial) > tt.tolerance {
+                t.Errorf("InitialBearing = %v, want %v", got, tt.initial)
+            }
+            if got := FinalBearing(tt.p1, tt.p2); math.Abs(got-tt.final) > tt.tolerance {
+                t.Errorf("FinalBearing = %v, want %v", got, tt.final)
+            }
+            // The final bearing is the reverse of the initial bearing of the way back
+            if got, want := FinalBearing(tt.p1, tt.p2), math.Mod(InitialBearing(tt.p2, tt.p1)+180, 360); math.Abs(got-want) > 1e-9 {
+                t.Errorf("FinalBearing = %v, want reverse bearing %v", got, want)
+            }
+        })
+    }
+}
+
+func TestBearingRange(t *testing.T) {
+    tests := []struct {
+        p1, p2 Point
+        want   float64
+    }{
+        {Point{0, 0}, Point{1, 0}, 0},
+        {Point{0, 0}, Point{0, 1}, 90},
+        {Point{0, 0}, Point{-1, 0}, 180},
+        {Point{0, 0}, Point{0, -1}, 270},
+        // Shorter way across ±180°
+        {Point{0, 179}, Point{0, -179}, 90},
+        {Point{0, -179}, Point{0, 179}, 270},
+    }
+    for _, tt := range tests {
+        got := InitialBearing(tt.p1, tt.p2)
+        if math.Abs(got-tt.want) > 1e-9 || got < 0 || got >= 360 {
+            t.Errorf("InitialBearing(%v, %v) = %v, want %v", tt.p1, tt.p2, got, tt.want)
+        }
+    }
+}
+
+func TestMidpoint(t *testing.T) {
+    tests := []struct {
+        name   string
+        p1, p2 Point
+        want   Point
+        tol    float64
+    }{
+        {"Land's End to John o' Groats", landsEnd, johnOGroats, Point{dms(54, 21, 44), -dms(4, 31, 50)}, arcSecond / 2},
+        {"Cambridge to Paris", cambridge, paris, Point{50.5363, 1.2746}, 5e-5},
+        {"across antimeridian", Point{0, 179}, Point{0, -179}, Point{0, 180}, 1e-9},
+        {"same point", paris, paris, paris, 1e-9},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := Midpoint(tt.p1, tt.p2)
+            if math.Abs(got.Lat-tt.want.Lat) > tt.tol || math.Abs(angNormalize(got.Lon-tt.want.Lon)) > tt.tol {
+                t.Errorf("Midpoint = %v, want %v", got, tt.want)
+            }
+            // Equidistant from both ends
+            if d1, d2 := DistanceHaversine(tt.p1, got), DistanceHaversine(got, tt.p2); math.Abs(d1-d2) > 1e-9 {
+                t.Errorf("Midpoint distances %v and %v differ", d1, d2)
+            }
+        })
+    }
+}
+
+func TestIntermediatePoint(t *testing.T) {
+    // Veness rounds to 0.0001°, about 6 m
+    if got, want := IntermediatePoint(cambridge, paris, 0.25), (Point{51.3721, 0.7073}); !got.Equal(want, 6) {
+        t.Errorf("IntermediatePoint(0.25) = %v, want %v", got, want)
+    }
+    if got := IntermediatePoint(cambridge, paris, 0); !got.Equal(cambridge, 1e-6) {
+        t.Errorf("IntermediatePoint(0) = %v, want %v", got, cambridge)
+    }
+    if got := IntermediatePoint(cambridge, paris, 1); !got.Equal(paris, 1e-6) {
+        t.Errorf("IntermediatePoint(1) = %v, want %v", got, paris)
+    }
+    if got, want := IntermediatePoint(landsEnd, johnOGroats, 0.5), Midpoint(landsEnd, johnOGroats); !got.Equal(want, 1e-6) {
+        t.Errorf("IntermediatePoint(0.5) = %v, want midpoint %v", got, want)
+    }
+    total := DistanceHaversine(landsEnd, johnOGroats)
+    if d := DistanceHaversine(landsEnd, IntermediatePoint(landsEnd, johnOGroats, 0.3)); math.Abs(d-0.3*total) > 1e-9 {
+        t.Errorf("IntermediatePoint(0.3) is %v km along, want %v", d, 0.3*total)
+    }
+}
+
+func TestDestination(t *testing.T) {
+    tests := []struct {
+        name     string
+        start    Point
+        bearing  float64
+        distance float64
+        want     Point
+        tol      float64 // Degrees; the distances are rounded, so positions are too
+    }{
+        {"Kinder Scout", kinderScout, dms(96, 1, 18), 124.8, Point{dms(53, 11, 18), dms(0, 8, 0)}, 1e-3},
+        {"Greenwich", greenwichObs, 300.7, 7.794, Point{51.5136, -0.0983}, 1e-4},
+        {"zero distance", paris, 123, 0, paris, 1e-12},
+        {"across antimeridian", Point{0, 179}, 90, 2 * EarthSphere.Radius * degToRad, Point{0, -179}, 1e-9},
+        {"over the pole", Point{89, 0}, 0, 2 * EarthSphere.Radius * degToRad, Point{89, 180}, 1e-9},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := Destination(tt.start, tt.bearing, tt.distance)
+            if math.Abs(got.Lat-tt.want.Lat) > tt.tol || math.Abs(angNormalize(got.Lon-tt.want.Lon)) > tt.tol {
+                t.Errorf("Destination = %v, want %v", got, tt.want)
+            }
+            if got.Lon < -180 || got.Lon > 180 {
+                t.Errorf("Destination longitude %v not normalized", got.Lon)
+            }
+        })
+    }
+
+    // Veness gives the final bearing on arrival at the Kinder Scout destination
+    dest := Destination(kinderScout, dms(96, 1, 18), 124.8)
+    if got, want := FinalBearing(kinderScout, dest), dms(97, 30, 52); math.Abs(got-want) > arcSecond/2 {
+        t.Errorf("final bearing = %v, want %v", got, want)
+    }
+    // Travelling the inverse distance along the initial bearing arrives at the other end
+    if got := Destination(landsEnd, InitialBearing(landsEnd, johnOGroats), DistanceHaversine(landsEnd, johnOGroats)); !got.Equal(johnOGroats, 1e-6) {
+        t.Errorf("Destination round trip = %v, want %v", got, johnOGroats)
+    }
+}
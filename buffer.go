@@ -0,0 +1,254 @@
+package geoutil
+
+import "math"
+
+// CapStyle selects how the ends of a buffered polyline are closed
+type CapStyle int
+
+const (
+    // CapRound closes ends with a half circle
+    CapRound CapStyle = iota
+    // CapFlat ends the buffer at the end points
+    CapFlat
+    // CapSquare extends the buffer past the end points by the distance
+    CapSquare
+)
+
+// JoinStyle selects how buffers are connected on the outside of a turn
+type JoinStyle int
+
+const (
+    // JoinRound connects offsets with a circular arc
+    JoinRound JoinStyle = iota
+    // JoinMiter extends offsets until they meet, up to MiterLimit
+    JoinMiter
+    // JoinBevel connects offsets with a straight edge
+    JoinBevel
+)
+
+// BufferOptions configures buffer generation
+type BufferOptions struct {
+    QuadrantSegments int       // Edges per quarter circle for round caps and joins (default 8)
+    Cap              CapStyle  // End style for polylines (default CapRound)
+    Join             JoinStyle // Corner style (default JoinRound)
+    MiterLimit       float64   // Longest miter as a multiple of the distance before beveling (default 5)
+}
+
+func (o BufferOptions) withDefaults() BufferOptions {
+    if o.QuadrantSegments <= 0 {
+        o.QuadrantSegments = 8
+    }
+    if o.MiterLimit < 1 {
+        o.MiterLimit = 5
+    }
+    return o
+}
+
+// BufferPoint builds a geodesic circle around a point on WGS84
+// Vertices are exactly distance away; the result works with FilterPointsInPolygonConcurrent
+// unless it crosses ±180°, where its Mode is ContainmentAntimeridian
+// p: Center
+// distance: Radius in kilometers
+// opts: QuadrantSegments sets the number of vertices (4 per segment)
+// Returns: Counter-clockwise polygon
+func BufferPoint(p Point, distance float64, opts BufferOptions) Polygon {
+    opts = opts.withDefaults()
+    pg := Polygon{Outer: geodesicCircle(p, distance, opts.QuadrantSegments)}
+    pg.Mode = bufferMode([][]Point{pg.Outer})
+    return pg
+}
+
+// BufferPolyline builds the area within distance of a polyline, e.g. customers near a road
+// Offsets are geodesic on WGS84 and joined by straight edges in lat/lon, so long
+// segments should be densified first; buffers must not reach a pole
+// line: Polyline vertices
+// distance: Buffer distance in kilometers
+// opts: Segments, cap and join styles
+// Returns: Buffered area, usually one polygon with holes where the line loops;
+// parts touching at a single point are separate members; empty for an empty line
+func BufferPolyline(line []Point, distance float64, opts BufferOptions) MultiPolygon {
+    opts = opts.withDefaults()
+    if distance <= 0 {
+        return nil
+    }
+    return withBufferMode(UnionAll(bufferPieces(line, false, distance, opts)))
+}
+
+// Buffer grows (positive distance) or shrinks (negative distance) the polygon
+// Holes shrink as the polygon grows and grow as it shrinks
+// distance: Buffer distance in kilometers
+// opts: Segments and join style; Cap is ignored
+// Returns: Buffered polygons; shrinking may split or remove the polygon
+func (pg Polygon) Buffer(distance float64, opts BufferOptions) MultiPolygon {
+    opts = opts.withDefaults()
+    base := MultiPolygon{pg}
+    if distance == 0 || len(pg.Outer) < 3 {
+        return base
+    }
+
+    var pieces []Polygon
+    for _, ring := range append([][]Point{pg.Outer}, pg.Holes...) {
+        pieces = append(pieces, bufferPieces(ring, true, math.Abs(distance), opts)...)
+    }
+    band := UnionAll(pieces)
+
+    var out MultiPolygon
+    if distance > 0 {
+        out = Union(base, band)
+    } else {
+        out = Difference(base, band)
+    }
+    return withBufferMode(out)
+}
+
+// Buffer grows or shrinks every polygon, merging members that grow into each other
+// distance: Buffer distance in kilometers
+// opts: Segments and join style
+// Returns: Buffered polygons
+func (mp MultiPolygon) Buffer(distance float64, opts BufferOptions) MultiPolygon {
+    var out MultiPolygon
+    for _, pg := range mp {
+        b := pg.Buffer(distance, opts)
+        if distance > 0 && len(out) > 0 {
+            out = withBufferMode(Union(out, b))
+        } else {
+            out = append(out, b...)
+        }
+    }
+    return out
+}
+
+// bufferPieces returns overlapping polygons whose union is the buffer of a polyline or ring:
+// a quadrilateral per segment plus join and cap pieces
+func bufferPieces(line []Point, closed bool, distance float64, opts BufferOptions) []Polygon {
+    var pts []Point
+    for _, p := range line {
+        if len(pts) == 0 || pts[len(pts)-1] != p {
+            pts = append(pts, p)
+        }
+    }
+    if closed {
+        pts = openRing(pts)
+    }
+
+    var pieces []Polygon
+    add := func(ring ...Point) {
+        pieces = append(pieces, Polygon{Outer: ring, Mode: ContainmentAntimeridian})
+    }
+    offset := func(p Point, bearing float64) Point {
+        q, _ := WGS84.Direct(p, bearing, distance)
+        return q
+    }
+
+    n := len(pts)
+    if n == 0 {
+        return nil
+    }
+    if n == 1 {
+        switch opts.Cap {
+        case CapRound:
+            add(geodesicCircle(pts[0], distance, opts.QuadrantSegments)...)
+        case CapSquare:
+            // Extend north and south by the distance, as the polyline cap does
+            north, azN := WGS84.Direct(pts[0], 0, distance)
+            south, azS := WGS84.Direct(pts[0], 180, distance)
+            add(offset(north, azN+90), offset(north, azN-90), offset(south, azS+90), offset(south, azS-90))
+        }
+        return pieces
+    }
+
+    segments := n - 1
+    if closed {
+        segments = n
+    }
+    start := make([]float64, segments)
+    end := make([]float64, segments)
+    for i := 0; i < segments; i++ {
+        a, b := pts[i], pts[(i+1)%n]
+        inv := WGS84.Inverse(a, b)
+        start[i], end[i] = inv.InitialBearing, inv.FinalBearing
+        // End points are kept as vertices so neighboring pieces share them exactly
+        add(offset(a, start[i]-90), a, offset(a, start[i]+90), offset(b, end[i]+90), b, offset(b, end[i]-90))
+    }
+
+    // Joins at vertices between two segments
+    for i := 0; i < n; i++ {
+        in, out := i-1, i
+        if !closed && (i == 0 || i == n-1) {
+            continue
+        }
+        if in < 0 {
+            in = segments - 1
+        }
+        v := pts[i]
+        if opts.Join == JoinRound {
+            add(geodesicCircle(v, distance, opts.QuadrantSegments)...)
+            continue
+        }
+        turn, _ := angDiff(end[in], start[out])
+        if math.Abs(turn) < 1e-9 || math.Abs(turn) > 180-1e-9 {
+            continue
+        }
+        // A right turn opens a gap on the left and vice versa
+        side := 90.0
+        if turn > 0 {
+            side = -90
+        }
+        p1, p2 := offset(v, end[in]+side), offset(v, start[out]+side)
+        ratio := 1 / math.Cos(math.Abs(turn)/2*degToRad)
+        if opts.Join == JoinMiter && ratio <= opts.MiterLimit {
+            m, _ := WGS84.Direct(v, end[in]+turn/2+side, distance*ratio)
+            add(v, p1, m, p2)
+        } else {
+            add(v, p1, p2)
+        }
+    }
+
+    if closed {
+        return pieces
+    }
+    a, b := pts[0], pts[n-1]
+    switch opts.Cap {
+    case CapRound:
+        add(geodesicCircle(a, distance, opts.QuadrantSegments)...)
+        add(geodesicCircle(b, distance, opts.QuadrantSegments)...)
+    case CapSquare:
+        e, az := WGS84.Direct(a, start[0]+180, distance)
+        add(offset(a, start[0]-90), a, offset(a, start[0]+90), offset(e, az-90), offset(e, az+90))
+        e, az = WGS84.Direct(b, end[segments-1], distance)
+        add(offset(b, end[segments-1]-90), b, offset(b, end[segments-1]+90), offset(e, az+90), offset(e, az-90))
+    }
+    return pieces
+}
+
+// geodesicCircle returns a counter-clockwise ring of points at distance from p
+func geodesicCircle(p Point, distance float64, quadrantSegments int) []Point {
+    n := 4 * quadrantSegments
+    ring := make([]Point, n)
+    for i := range ring {
+        // Bearings run clockwise, so walk them backwards
+        ring[i], _ = WGS84.Direct(p, -360*float64(i)/float64(n), distance)
+    }
+    return ring
+}
+
+// withBufferMode sets each polygon's Mode from its rings
+func withBufferMode(mp MultiPolygon) MultiPolygon {
+    for i := range mp {
+        mp[i].Mode = bufferMode(append([][]Point{mp[i].Outer}, mp[i].Holes...))
+    }
+    return mp
+}
+
+// bufferMode returns ContainmentAntimeridian if any edge crosses ±180°, otherwise
+// ContainmentPlanar so the rings can be used with the planar functions directly
+func bufferMode(rings [][]Point) ContainmentMode {
+    for _, ring := range rings {
+        for i := range ring {
+            if math.Abs(ring[i].Lon-ring[(i+1)%len(ring)].Lon) > 180 {
+                return ContainmentAntimeridian
+            }
+        }
+    }
+    return ContainmentPlanar
+}
\ No newline at end of file
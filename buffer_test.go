@@ -0,0 +1,249 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// offsetPoint moves p by distance kilometers along bearing on WGS84
+func offsetPoint(p Point, bearing, distance float64) Point {
+    q, _ := WGS84.Direct(p, bearing, distance)
+    return q
+}
+
+func TestBufferPoint(t *testing.T) {
+    tests := []struct {
+        name     string
+        center   Point
+        distance float64
+        mode     ContainmentMode
+    }{
+        {"equator", Point{0, 0}, 10, ContainmentPlanar},
+        {"mid latitude", Point{55.75, 37.62}, 25, ContainmentPlanar},
+        {"southern", Point{-33.87, 151.21}, 1, ContainmentPlanar},
+        {"antimeridian", Point{-17.7, 179.98}, 10, ContainmentAntimeridian},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            pg := BufferPoint(tt.center, tt.distance, BufferOptions{QuadrantSegments: 4})
+            if len(pg.Outer) != 16 {
+                t.Fatalf("got %d vertices, want 16", len(pg.Outer))
+            }
+            if pg.Mode != tt.mode {
+                t.Errorf("Mode = %v, want %v", pg.Mode, tt.mode)
+            }
+            for _, v := range pg.Outer {
+                if d := WGS84.Distance(tt.center, v); math.Abs(d-tt.distance) > 1e-9 {
+                    t.Errorf("vertex %v is %v km from the center, want %v", v, d, tt.distance)
+                }
+            }
+            if area := pg.Area(WGS84); area <= 0 {
+                t.Errorf("area = %v, want a counter-clockwise ring", area)
+            }
+            for _, bearing := range []float64{0, 45, 90, 200, 315} {
+                if in := offsetPoint(tt.center, bearing, 0.9*tt.distance); !pg.Contains(in) {
+                    t.Errorf("%v at 0.9 radius not contained", in)
+                }
+                if out := offsetPoint(tt.center, bearing, 1.1*tt.distance); pg.Contains(out) {
+                    t.Errorf("%v at 1.1 radius contained", out)
+                }
+            }
+        })
+    }
+}
+
+func TestBufferPolyline(t *testing.T) {
+    line := []Point{{0, 0}, {0, 1}, {0.5, 1.5}}
+    const d = 10.0
+    // Points beside the middle of each segment and beyond the ends
+    beside := []Point{offsetPoint(Point{0, 0.5}, 0, 0.9*d), offsetPoint(Point{0, 0.5}, 180, 0.9*d), offsetPoint(Point{0.25, 1.25}, 135, 0.9*d)}
+    away := []Point{offsetPoint(Point{0, 0.5}, 0, 1.1*d), offsetPoint(Point{0, 0.5}, 180, 1.1*d), offsetPoint(Point{0.25, 1.25}, 315, 1.1*d)}
+    beyondStart := offsetPoint(line[0], 270, 0.9*d)
+    // Outside corner of the left turn at (0, 1)
+    corner := offsetPoint(line[1], 157.5, 1.05*d)
+
+    tests := []struct {
+        name        string
+        opts        BufferOptions
+        start       bool // Whether beyondStart is covered
+        cornerPoint bool // Whether corner is covered
+    }{
+        {"round", BufferOptions{}, true, false},
+        {"flat miter", BufferOptions{Cap: CapFlat, Join: JoinMiter}, false, true},
+        {"square bevel", BufferOptions{Cap: CapSquare, Join: JoinBevel}, true, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            mp := BufferPolyline(line, d, tt.opts)
+            if len(mp) != 1 || len(mp[0].Holes) != 0 {
+                t.Fatalf("got %d polygons, want one without holes", len(mp))
+            }
+            for _, p := range beside {
+                if !mp.Contains(p) {
+                    t.Errorf("%v within the distance not contained", p)
+                }
+            }
+            for _, p := range away {
+                if mp.Contains(p) {
+                    t.Errorf("%v beyond the distance contained", p)
+                }
+            }
+            if got := mp.Contains(beyondStart); got != tt.start {
+                t.Errorf("Contains(beyond start) = %v, want %v", got, tt.start)
+            }
+            if got := mp.Contains(corner); got != tt.cornerPoint {
+                t.Errorf("Contains(outside corner) = %v, want %v", got, tt.cornerPoint)
+            }
+        })
+    }
+}
+
+func TestBufferPolylineLoop(t *testing.T) {
+    // Crosses its first segment, enclosing about a square degree
+    line := []Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0.2, -0.2}, {0.2, 1.3}}
+    mp := BufferPolyline(line, 5, BufferOptions{})
+    if len(mp) != 1 || len(mp[0].Holes) != 1 {
+        t.Fatalf("got %v, want one polygon with one hole", mp)
+    }
+    if mp.Contains(Point{0.6, 0.5}) {
+        t.Error("center of the loop contained")
+    }
+    if !mp.Contains(Point{0.2, 0.5}) || !mp.Contains(Point{0, 0.5}) {
+        t.Error("points on the line not contained")
+    }
+    if hole := mp[0].Holes[0]; planarArea(hole) >= 0 {
+        t.Error("hole is not clockwise")
+    }
+}
+
+func TestBufferPolylineAntimeridian(t *testing.T) {
+    mp := BufferPolyline([]Point{{10, 179.5}, {10, -179.5}}, 5, BufferOptions{})
+    if len(mp) != 1 || mp[0].Mode != ContainmentAntimeridian {
+        t.Fatalf("got %v, want one polygon in ContainmentAntimeridian mode", mp)
+    }
+    for _, p := range []Point{{10, 180}, {10, -180}, {10.03, 179.9}, {9.97, -179.6}} {
+        if !mp.Contains(p) {
+            t.Errorf("%v not contained", p)
+        }
+    }
+    for _, p := range []Point{{10, 0}, {10.06, 180}, {10, 179}} {
+        if mp.Contains(p) {
+            t.Errorf("%v contained", p)
+        }
+    }
+}
+
+func TestBufferPolylineEmpty(t *testing.T) {
+    if mp := BufferPolyline(nil, 5, BufferOptions{}); len(mp) != 0 {
+        t.Errorf("empty line: got %v", mp)
+    }
+    if mp := BufferPolyline([]Point{{0, 0}, {0, 1}}, 0, BufferOptions{}); len(mp) != 0 {
+        t.Errorf("zero distance: got %v", mp)
+    }
+    if mp := BufferPolyline([]Point{{0, 0}, {0, 1}}, -5, BufferOptions{}); len(mp) != 0 {
+        t.Errorf("negative distance: got %v", mp)
+    }
+    // A single point is buffered by its cap
+    if mp := BufferPolyline([]Point{{0, 0}}, 5, BufferOptions{}); len(mp) != 1 || !mp.Contains(offsetPoint(Point{0, 0}, 60, 4)) {
+        t.Errorf("single point: got %v", mp)
+    }
+}
+
+func TestPolygonBuffer(t *testing.T) {
+    square := Polygon{
+        Outer: []Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}},
+        Holes: [][]Point{{{0.4, 0.4}, {0.6, 0.4}, {0.6, 0.6}, {0.4, 0.6}}},
+    }
+    east := Point{0.5, 1}
+    holeEdge := Point{0.5, 0.6}
+
+    grown := square.Buffer(5, BufferOptions{})
+    if len(grown) != 1 || len(grown[0].Holes) != 1 {
+        t.Fatalf("grown: got %v, want one polygon with one hole", grown)
+    }
+    if !grown.Contains(offsetPoint(east, 90, 4)) || grown.Contains(offsetPoint(east, 90, 6)) {
+        t.Error("grown outer edge is not 5 km out")
+    }
+    // The hole shrinks by the same distance
+    if !grown.Contains(offsetPoint(holeEdge, 270, 4)) || grown.Contains(offsetPoint(holeEdge, 270, 6)) {
+        t.Error("hole did not shrink by 5 km")
+    }
+    if grown.Area(WGS84) <= square.Area(WGS84) {
+        t.Error("grown area is not larger")
+    }
+
+    shrunk := square.Buffer(-5, BufferOptions{})
+    if len(shrunk) != 1 || len(shrunk[0].Holes) != 1 {
+        t.Fatalf("shrunk: got %v, want one polygon with one hole", shrunk)
+    }
+    if !shrunk.Contains(offsetPoint(east, 270, 6)) || shrunk.Contains(offsetPoint(east, 270, 4)) {
+        t.Error("shrunk outer edge is not 5 km in")
+    }
+    if !shrunk.Contains(offsetPoint(holeEdge, 90, 6)) || shrunk.Contains(offsetPoint(holeEdge, 90, 4)) {
+        t.Error("hole did not grow by 5 km")
+    }
+
+    // The ring between the hole and the outer edge is about 44 km wide
+    if gone := square.Buffer(-30, BufferOptions{}); len(gone) != 0 {
+        t.Errorf("shrinking past the width: got %v, want nothing", gone)
+    }
+    if same := square.Buffer(0, BufferOptions{}); len(same) != 1 || len(same[0].Outer) != 4 {
+        t.Errorf("zero distance: got %v, want the input", same)
+    }
+}
+
+func TestPolygonBufferSplits(t *testing.T) {
+    // Two squares joined by a neck about 11 km wide
+    dumbbell := Polygon{Outer: []Point{
+        {0, 0}, {0, 1}, {0.45, 1}, {0.45, 2}, {0, 2}, {0, 3},
+        {1, 3}, {1, 2}, {0.55, 2}, {0.55, 1}, {1, 1}, {1, 0},
+    }}
+    mp := dumbbell.Buffer(-10, BufferOptions{Join: JoinMiter})
+    if len(mp) != 2 {
+        t.Fatalf("got %d polygons, want the neck to split the shape in two", len(mp))
+    }
+    if !mp.Contains(Point{0.5, 0.5}) || !mp.Contains(Point{0.5, 2.5}) || mp.Contains(Point{0.5, 1.5}) {
+        t.Error("wrong parts kept")
+    }
+}
+
+func TestPolygonBufferAntimeridian(t *testing.T) {
+    pg := Polygon{Outer: fijiRing, Mode: ContainmentAntimeridian}
+    mp := pg.Buffer(20, BufferOptions{})
+    if len(mp) != 1 || mp[0].Mode != ContainmentAntimeridian {
+        t.Fatalf("got %v, want one polygon in ContainmentAntimeridian mode", mp)
+    }
+    for _, p := range []Point{offsetPoint(Point{-15, -170}, 90, 15), offsetPoint(Point{-15, 170}, 270, 15), {-20.1, 180}, {-9.9, -180}} {
+        if !mp.Contains(p) {
+            t.Errorf("%v within 20 km not contained", p)
+        }
+    }
+    for _, p := range []Point{offsetPoint(Point{-15, -170}, 90, 25), {-15, 0}, {-21, 180}} {
+        if mp.Contains(p) {
+            t.Errorf("%v contained", p)
+        }
+    }
+
+    shrunk := pg.Buffer(-20, BufferOptions{})
+    if len(shrunk) != 1 || !shrunk.Contains(Point{-15, 180}) || shrunk.Contains(offsetPoint(Point{-15, -170}, 270, 15)) {
+        t.Errorf("shrunk: got %v", shrunk)
+    }
+}
+
+func TestMultiPolygonBuffer(t *testing.T) {
+    // Two squares about 11 km apart
+    mp := MultiPolygon{
+        {Outer: []Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}}},
+        {Outer: []Point{{0, 1.1}, {0, 2}, {1, 2}, {1, 1.1}}},
+    }
+    if got := mp.Buffer(8, BufferOptions{}); len(got) != 1 || !got.Contains(Point{0.5, 1.05}) {
+        t.Errorf("overlapping growth: got %d polygons, want members merged", len(got))
+    }
+    if got := mp.Buffer(2, BufferOptions{}); len(got) != 2 || got.Contains(Point{0.5, 1.05}) {
+        t.Errorf("small growth: got %d polygons, want 2", len(got))
+    }
+    shrunk := mp.Buffer(-5, BufferOptions{})
+    if len(shrunk) != 2 || shrunk.Contains(offsetPoint(Point{0.5, 1}, 270, 4)) || !shrunk.Contains(Point{0.5, 1.5}) {
+        t.Errorf("shrunk: got %v", shrunk)
+    }
+}
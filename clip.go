@@ -0,0 +1,507 @@
+package geoutil
+
+import (
+    "math"
+    "sort"
+)
+
+// clipOp selects which edges of the overlaid polygons form the result
+type clipOp int
+
+const (
+    clipIntersection clipOp = iota
+    clipUnion
+    clipDifference
+)
+
+// Intersection computes the area covered by both a and b
+// Edges are straight lines in lat/lon; if any input polygon uses a non-planar
+// Mode, longitudes are unwrapped so shapes crossing ±180° are clipped correctly
+// and the result uses ContainmentAntimeridian. ContainmentSpherical input is
+// clipped along its straight lat/lon edges too, so densify long great-circle edges first
+// Rings enclosing a pole are not supported
+// a, b: Multipolygons, each with non-overlapping members
+// Returns: Polygons with counter-clockwise outer rings and clockwise holes
+func Intersection(a, b MultiPolygon) MultiPolygon {
+    return clip(a, b, clipIntersection)
+}
+
+// Union computes the area covered by a or b
+// a, b: Multipolygons, each with non-overlapping members
+// Returns: Polygons with counter-clockwise outer rings and clockwise holes
+func Union(a, b MultiPolygon) MultiPolygon {
+    return clip(a, b, clipUnion)
+}
+
+// Difference computes the area covered by a but not by b
+// a, b: Multipolygons, each with non-overlapping members
+// Returns: Polygons with counter-clockwise outer rings and clockwise holes
+func Difference(a, b MultiPolygon) MultiPolygon {
+    return clip(a, b, clipDifference)
+}
+
+// UnionAll merges any number of possibly overlapping polygons
+// polygons: Polygons to merge, e.g. delivery zones
+// Returns: Merged coverage
+func UnionAll(polygons []Polygon) MultiPolygon {
+    switch len(polygons) {
+    case 0:
+        return nil
+    case 1:
+        return Union(MultiPolygon{polygons[0]}, nil)
+    }
+    mid := len(polygons) / 2
+    return Union(UnionAll(polygons[:mid]), UnionAll(polygons[mid:]))
+}
+
+// clipEps is the relative tolerance for parallel segments
+const clipEps = 1e-12
+
+// clipSnap is the coordinate grid in degrees used to merge nearly equal vertices
+const clipSnap = 1e-10
+
+// clipEdge is a directed edge of an input ring; interior lies on its left
+type clipEdge struct {
+    a, b   Point
+    set    int // 0 for the first operand, 1 for the second
+    splits []Point
+}
+
+// clip overlays a and b, selects edges for op and rebuilds polygons from them
+func clip(a, b MultiPolygon, op clipOp) MultiPolygon {
+    mode, lon0 := clipFrame(a, b)
+    frame := clipTransform(mode, lon0)
+    pa, ea := clipPrepare(a, 0, frame)
+    pb, eb := clipPrepare(b, 1, frame)
+    subs := splitEdges(append(ea, eb...))
+
+    // Index sub-edges by their undirected endpoints to find shared boundaries
+    shared := make(map[[2]Point][]int)
+    for i, e := range subs {
+        key := undirectedKey(e.a, e.b)
+        shared[key] = append(shared[key], i)
+    }
+
+    // Classify against prepared operands; sub-edge counts grow with vertex counts
+    prepared := [2]*PreparedPolygon{NewPreparedMultiPolygon(pa), NewPreparedMultiPolygon(pb)}
+    var selected [][2]Point
+    for _, s := range subs {
+        other := prepared[1-s.set]
+        twin := -1
+        for _, j := range shared[undirectedKey(s.a, s.b)] {
+            if subs[j].set != s.set {
+                twin = j
+                break
+            }
+        }
+
+        if twin >= 0 {
+            // Boundary shared by both operands: keep at most one copy
+            sameDir := subs[twin].a == s.a
+            if s.set != 0 {
+                continue
+            }
+            if (op == clipDifference) != sameDir {
+                selected = append(selected, [2]Point{s.a, s.b})
+            }
+            continue
+        }
+
+        mid := Point{Lat: (s.a.Lat + s.b.Lat) / 2, Lon: (s.a.Lon + s.b.Lon) / 2}
+        inside := other.Contains(mid)
+        switch {
+        case op == clipIntersection && inside,
+            op == clipUnion && !inside,
+            op == clipDifference && s.set == 0 && !inside:
+            selected = append(selected, [2]Point{s.a, s.b})
+        case op == clipDifference && s.set == 1 && inside:
+            // Parts of b inside a bound the result with b's interior outside
+            selected = append(selected, [2]Point{s.b, s.a})
+        }
+    }
+
+    rings := chainRings(selected)
+    return assemblePolygons(rings, mode)
+}
+
+// clipFrame picks the containment mode of the result and the unwrapping center
+// Intersections are computed on straight unwrapped edges, so any non-planar
+// input gives ContainmentAntimeridian
+func clipFrame(a, b MultiPolygon) (ContainmentMode, float64) {
+    mode := ContainmentPlanar
+    var pts []Point
+    for _, mp := range []MultiPolygon{a, b} {
+        for _, pg := range mp {
+            if pg.Mode != ContainmentPlanar {
+                mode = ContainmentAntimeridian
+            }
+            pts = append(pts, pg.Outer...)
+        }
+    }
+    return mode, newLocalProjection(pts).lon0
+}
+
+// clipTransform maps points to the working frame: longitudes are unwrapped around
+// lon0 for non-planar modes and coordinates are snapped to the clipping grid
+func clipTransform(mode ContainmentMode, lon0 float64) func(Point) Point {
+    return func(p Point) Point {
+        if mode != ContainmentPlanar {
+            p.Lon = lon0 + angNormalize(p.Lon-lon0)
+        }
+        return Point{Lat: snapCoord(p.Lat), Lon: snapCoord(p.Lon)}
+    }
+}
+
+// clipPrepare converts a multipolygon to the working frame with consistently oriented rings
+// Returns: The converted multipolygon for containment tests and its edges
+func clipPrepare(mp MultiPolygon, set int, frame func(Point) Point) (MultiPolygon, []*clipEdge) {
+    var out MultiPolygon
+    var edges []*clipEdge
+    for _, pg := range mp {
+        outer := clipRing(pg.Outer, frame, true)
+        if len(outer) < 3 {
+            continue
+        }
+        conv := Polygon{Outer: outer}
+        for _, hole := range pg.Holes {
+            if h := clipRing(hole, frame, false); len(h) >= 3 {
+                conv.Holes = append(conv.Holes, h)
+            }
+        }
+        out = append(out, conv)
+        for _, ring := range append([][]Point{conv.Outer}, conv.Holes...) {
+            for i := range ring {
+                edges = append(edges, &clipEdge{a: ring[i], b: ring[(i+1)%len(ring)], set: set})
+            }
+        }
+    }
+    return out, edges
+}
+
+// clipRing converts ring vertices, drops repeats and orients the ring
+// ccw: Orient counter-clockwise (outer ring) instead of clockwise (hole)
+func clipRing(ring []Point, frame func(Point) Point, ccw bool) []Point {
+    out := make([]Point, 0, len(ring))
+    for _, p := range ring {
+        q := frame(p)
+        if len(out) == 0 || out[len(out)-1] != q {
+            out = append(out, q)
+        }
+    }
+    for len(out) > 1 && out[0] == out[len(out)-1] {
+        out = out[:len(out)-1]
+    }
+    if (planarArea(out) > 0) != ccw {
+        for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+            out[i], out[j] = out[j], out[i]
+        }
+    }
+    return out
+}
+
+// clipRounds bounds how often sub-edges are re-intersected after splitting
+const clipRounds = 8
+
+// splitEdges splits edges at every intersection, including edges of the same
+// operand so that members touching at a point split each other consistently
+// Split points are snapped to the grid, which can move sub-edges across nearby
+// intersections, so the sub-edges are intersected again until nothing changes
+// Returns: Non-degenerate sub-edges
+func splitEdges(edges []*clipEdge) []*clipEdge {
+    for round := 0; round < clipRounds; round++ {
+        if !findSplits(edges) {
+            break
+        }
+        var pieces []*clipEdge
+        for _, e := range edges {
+            pts := append(append([]Point{e.a}, e.splits...), e.b)
+            for i := 0; i+1 < len(pts); i++ {
+                if pts[i] != pts[i+1] {
+                    pieces = append(pieces, &clipEdge{a: pts[i], b: pts[i+1], set: e.set})
+                }
+            }
+        }
+        edges = pieces
+    }
+    return edges
+}
+
+// findSplits records the intersections between edges as sorted split points
+// Split points are computed once per pair so both edges share identical vertices
+// Returns: Whether any edge gained a split point
+func findSplits(edges []*clipEdge) bool {
+    order := make([]*clipEdge, len(edges))
+    copy(order, edges)
+    sort.Slice(order, func(i, j int) bool {
+        return math.Min(order[i].a.Lon, order[i].b.Lon) < math.Min(order[j].a.Lon, order[j].b.Lon)
+    })
+
+    for i, e := range order {
+        maxLon := math.Max(e.a.Lon, e.b.Lon)
+        for _, f := range order[i+1:] {
+            if math.Min(f.a.Lon, f.b.Lon) > maxLon {
+                break
+            }
+            if math.Max(f.a.Lat, f.b.Lat) < math.Min(e.a.Lat, e.b.Lat) ||
+                math.Min(f.a.Lat, f.b.Lat) > math.Max(e.a.Lat, e.b.Lat) {
+                continue
+            }
+            intersectEdges(e, f)
+        }
+    }
+
+    split := false
+    for _, e := range edges {
+        if len(e.splits) == 0 {
+            continue
+        }
+        split = true
+        a := e.a
+        sort.Slice(e.splits, func(i, j int) bool {
+            return sqDist(a, e.splits[i]) < sqDist(a, e.splits[j])
+        })
+    }
+    return split
+}
+
+// intersectEdges adds the intersection points of e and f to their split lists
+func intersectEdges(e, f *clipEdge) {
+    // Endpoints within snapping distance of the other edge split it there,
+    // which also handles collinear overlaps
+    touched := false
+    for _, p := range []Point{f.a, f.b} {
+        if nearInterior(e, p) {
+            e.splits = append(e.splits, p)
+            touched = true
+        }
+    }
+    for _, p := range []Point{e.a, e.b} {
+        if nearInterior(f, p) {
+            f.splits = append(f.splits, p)
+            touched = true
+        }
+    }
+    if touched || e.a == f.a || e.a == f.b || e.b == f.a || e.b == f.b {
+        return
+    }
+
+    rx, ry := e.b.Lon-e.a.Lon, e.b.Lat-e.a.Lat
+    sx, sy := f.b.Lon-f.a.Lon, f.b.Lat-f.a.Lat
+    qx, qy := f.a.Lon-e.a.Lon, f.a.Lat-e.a.Lat
+    d := rx*sy - ry*sx
+    if math.Abs(d) <= clipEps*math.Hypot(rx, ry)*math.Hypot(sx, sy) {
+        return
+    }
+    t := (qx*sy - qy*sx) / d
+    u := (qx*ry - qy*rx) / d
+    if t < 0 || t > 1 || u < 0 || u > 1 {
+        return
+    }
+    x := Point{Lat: snapCoord(e.a.Lat + t*ry), Lon: snapCoord(e.a.Lon + t*rx)}
+    // Reuse exact endpoints where the crossing is at a vertex
+    for _, p := range []Point{e.a, e.b, f.a, f.b} {
+        if sqDist(x, p) <= sq(2*clipSnap) {
+            x = p
+            break
+        }
+    }
+    if x != e.a && x != e.b {
+        e.splits = append(e.splits, x)
+    }
+    if x != f.a && x != f.b {
+        f.splits = append(f.splits, x)
+    }
+}
+
+// nearInterior reports whether p lies within two grid steps of the interior of e
+func nearInterior(e *clipEdge, p Point) bool {
+    if p == e.a || p == e.b {
+        return false
+    }
+    t := projectParam(e, p)
+    if t <= 0 || t >= 1 {
+        return false
+    }
+    rx, ry := e.b.Lon-e.a.Lon, e.b.Lat-e.a.Lat
+    cross := rx*(p.Lat-e.a.Lat) - ry*(p.Lon-e.a.Lon)
+    return math.Abs(cross) <= 2*clipSnap*math.Hypot(rx, ry)
+}
+
+// projectParam returns the position of p along e as a fraction of its length
+func projectParam(e *clipEdge, p Point) float64 {
+    rx, ry := e.b.Lon-e.a.Lon, e.b.Lat-e.a.Lat
+    l2 := rx*rx + ry*ry
+    if l2 == 0 {
+        return 0
+    }
+    return ((p.Lon-e.a.Lon)*rx + (p.Lat-e.a.Lat)*ry) / l2
+}
+
+// chainRings links directed edges into closed rings
+// At vertices with several outgoing edges the walk takes the sharpest left turn,
+// which separates polygons touching at a point; rings touching themselves are split
+func chainRings(edges [][2]Point) [][]Point {
+    out := make(map[Point][]int)
+    for i, e := range edges {
+        out[e[0]] = append(out[e[0]], i)
+    }
+    used := make([]bool, len(edges))
+
+    var rings [][]Point
+    for start := range edges {
+        if used[start] {
+            continue
+        }
+        used[start] = true
+        ring := []Point{edges[start][0]}
+        prev, cur := edges[start][0], edges[start][1]
+        for cur != ring[0] {
+            next := -1
+            best := math.Inf(1)
+            back := math.Atan2(prev.Lat-cur.Lat, prev.Lon-cur.Lon)
+            for _, k := range out[cur] {
+                if used[k] {
+                    continue
+                }
+                e := edges[k]
+                // Clockwise angle from the reverse of the incoming edge
+                θ := back - math.Atan2(e[1].Lat-cur.Lat, e[1].Lon-cur.Lon)
+                for θ <= 0 {
+                    θ += 2 * math.Pi
+                }
+                if θ < best {
+                    next, best = k, θ
+                }
+            }
+            if next < 0 {
+                // Dead end from numerical noise; drop the partial ring
+                ring = nil
+                break
+            }
+            used[next] = true
+            ring = append(ring, cur)
+            prev, cur = cur, edges[next][1]
+        }
+        rings = append(rings, splitPinches(ring)...)
+    }
+    return rings
+}
+
+// splitPinches splits a ring that visits a vertex more than once into simple loops,
+// such as a hole touching its outer ring at a point
+func splitPinches(ring []Point) [][]Point {
+    var loops [][]Point
+    var stack []Point
+    at := make(map[Point]int, len(ring))
+    for _, p := range ring {
+        if k, ok := at[p]; ok {
+            loop := append([]Point(nil), stack[k:]...)
+            if len(loop) >= 3 {
+                loops = append(loops, loop)
+            }
+            for _, q := range stack[k+1:] {
+                delete(at, q)
+            }
+            stack = stack[:k+1]
+            continue
+        }
+        at[p] = len(stack)
+        stack = append(stack, p)
+    }
+    if len(stack) >= 3 {
+        loops = append(loops, stack)
+    }
+    return loops
+}
+
+// assemblePolygons sorts rings into outers (counter-clockwise) and holes (clockwise)
+// and assigns each hole to the smallest outer ring containing it
+func assemblePolygons(rings [][]Point, mode ContainmentMode) MultiPolygon {
+    var outers, holes [][]Point
+    var outerAreas []float64
+    for _, r := range rings {
+        r = dropCollinear(r)
+        if len(r) < 3 {
+            continue
+        }
+        area := planarArea(r)
+        switch {
+        case area > 0:
+            outers = append(outers, r)
+            outerAreas = append(outerAreas, area)
+        case area < 0:
+            holes = append(holes, r)
+        }
+    }
+
+    result := make(MultiPolygon, len(outers))
+    for i, r := range outers {
+        result[i] = Polygon{Outer: r}
+    }
+    for _, h := range holes {
+        probe := Point{Lat: (h[0].Lat + h[1].Lat) / 2, Lon: (h[0].Lon + h[1].Lon) / 2}
+        best := -1
+        for i, r := range outers {
+            if IsPointInPolygon(probe, r) && (best < 0 || outerAreas[i] < outerAreas[best]) {
+                best = i
+            }
+        }
+        if best >= 0 {
+            result[best].Holes = append(result[best].Holes, h)
+        }
+    }
+
+    for i := range result {
+        result[i].Mode = mode
+        if mode == ContainmentPlanar {
+            continue
+        }
+        for _, r := range append([][]Point{result[i].Outer}, result[i].Holes...) {
+            for k := range r {
+                r[k].Lon = angNormalize(r[k].Lon)
+            }
+        }
+    }
+    return result
+}
+
+// dropCollinear removes vertices lying on the straight line between their neighbors,
+// such as split points left on edges that ended up unchanged
+func dropCollinear(ring []Point) []Point {
+    out := make([]Point, 0, len(ring))
+    for i, p := range ring {
+        prev, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+        if orient(prev, p, next) == 0 && (p.Lat-prev.Lat)*(next.Lat-p.Lat)+(p.Lon-prev.Lon)*(next.Lon-p.Lon) > 0 {
+            continue
+        }
+        out = append(out, p)
+    }
+    return out
+}
+
+// planarArea returns the shoelace area of a ring in lon/lat, counter-clockwise positive
+func planarArea(ring []Point) float64 {
+    area := 0.0
+    for i := range ring {
+        a, b := ring[i], ring[(i+1)%len(ring)]
+        area += a.Lon*b.Lat - b.Lon*a.Lat
+    }
+    return area / 2
+}
+
+// undirectedKey orders the endpoints of an edge so both directions share a key
+func undirectedKey(a, b Point) [2]Point {
+    if a.Lat < b.Lat || (a.Lat == b.Lat && a.Lon < b.Lon) {
+        return [2]Point{a, b}
+    }
+    return [2]Point{b, a}
+}
+
+// snapCoord rounds a coordinate to the clipping grid
+func snapCoord(x float64) float64 {
+    return math.Round(x/clipSnap) * clipSnap
+}
+
+func sqDist(a, b Point) float64 {
+    return sq(a.Lat-b.Lat) + sq(a.Lon-b.Lon)
+}
\ No newline at end of file
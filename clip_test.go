@@ -0,0 +1,179 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// box returns a counter-clockwise rectangle polygon
+func box(minLat, minLon, maxLat, maxLon float64) Polygon {
+    return Polygon{Outer: []Point{{minLat, minLon}, {minLat, maxLon}, {maxLat, maxLon}, {maxLat, minLon}}}
+}
+
+// checkOverlay compares the result's containment with op applied to the inputs
+// on a grid offset from the whole and half degrees used by the test shapes
+func checkOverlay(t *testing.T, got, a, b MultiPolygon, op func(inA, inB bool) bool) {
+    t.Helper()
+    for lat := -1.0; lat <= 6; lat += 0.25 {
+        for lon := -1.0; lon <= 6; lon += 0.25 {
+            p := Point{Lat: lat + 0.0371, Lon: lon + 0.0613}
+            if want := op(a.Contains(p), b.Contains(p)); got.Contains(p) != want {
+                t.Errorf("Contains(%v) = %v, want %v", p, !want, want)
+            }
+        }
+    }
+    for _, pg := range got {
+        if planarArea(pg.Outer) <= 0 {
+            t.Errorf("outer ring %v is not counter-clockwise", pg.Outer)
+        }
+        for _, h := range pg.Holes {
+            if planarArea(h) >= 0 {
+                t.Errorf("hole %v is not clockwise", h)
+            }
+        }
+    }
+}
+
+// holes counts the holes of all members
+func holes(mp MultiPolygon) int {
+    n := 0
+    for _, pg := range mp {
+        n += len(pg.Holes)
+    }
+    return n
+}
+
+func TestOverlay(t *testing.T) {
+    square := box(0, 0, 4, 4)
+    withHole := Polygon{Outer: square.Outer, Holes: [][]Point{box(1, 1, 3, 3).Outer}}
+    tests := []struct {
+        name string
+        a, b MultiPolygon
+        // Members and holes of intersection, union and difference
+        counts [3][2]int
+    }{
+        {"overlapping", MultiPolygon{box(0, 0, 2, 2)}, MultiPolygon{box(1, 1, 3, 3)}, [3][2]int{{1, 0}, {1, 0}, {1, 0}}},
+        {"shared edge", MultiPolygon{box(0, 0, 1, 1)}, MultiPolygon{box(0, 1, 1, 2)}, [3][2]int{{0, 0}, {1, 0}, {1, 0}}},
+        {"partly shared edge", MultiPolygon{box(0, 0, 2, 1)}, MultiPolygon{box(1, 1, 3, 2)}, [3][2]int{{0, 0}, {1, 0}, {1, 0}}},
+        {"shared corner", MultiPolygon{box(0, 0, 1, 1)}, MultiPolygon{box(1, 1, 2, 2)}, [3][2]int{{0, 0}, {2, 0}, {1, 0}}},
+        {"disjoint", MultiPolygon{box(0, 0, 1, 1)}, MultiPolygon{box(3, 3, 4, 4)}, [3][2]int{{0, 0}, {2, 0}, {1, 0}}},
+        {"nested", MultiPolygon{square}, MultiPolygon{box(1, 1, 2, 2)}, [3][2]int{{1, 0}, {1, 0}, {1, 1}}},
+        {"nested touching", MultiPolygon{square}, MultiPolygon{box(0, 1, 2, 2)}, [3][2]int{{1, 0}, {1, 0}, {1, 0}}},
+        {"identical", MultiPolygon{square}, MultiPolygon{square}, [3][2]int{{1, 0}, {1, 0}, {0, 0}}},
+        {"hole overlapped", MultiPolygon{withHole}, MultiPolygon{box(2, 2, 5, 5)}, [3][2]int{{1, 0}, {1, 1}, {1, 0}}},
+        {"hole filled", MultiPolygon{withHole}, MultiPolygon{box(1, 1, 3, 3)}, [3][2]int{{0, 0}, {1, 0}, {1, 1}}},
+        {"inside hole", MultiPolygon{withHole}, MultiPolygon{box(1.5, 1.5, 2.5, 2.5)}, [3][2]int{{0, 0}, {2, 1}, {1, 1}}},
+        {"multipolygon", MultiPolygon{box(0, 0, 1, 4), box(3, 0, 4, 4)}, MultiPolygon{box(0, 1, 4, 2)}, [3][2]int{{2, 0}, {1, 0}, {4, 0}}},
+    }
+    ops := []struct {
+        name string
+        fn   func(a, b MultiPolygon) MultiPolygon
+        op   func(inA, inB bool) bool
+    }{
+        {"Intersection", Intersection, func(a, b bool) bool { return a && b }},
+        {"Union", Union, func(a, b bool) bool { return a || b }},
+        {"Difference", Difference, func(a, b bool) bool { return a && !b }},
+    }
+    for _, tt := range tests {
+        for k, op := range ops {
+            t.Run(tt.name+"/"+op.name, func(t *testing.T) {
+                got := op.fn(tt.a, tt.b)
+                if len(got) != tt.counts[k][0] || holes(got) != tt.counts[k][1] {
+                    t.Errorf("got %d polygons with %d holes, want %d with %d", len(got), holes(got), tt.counts[k][0], tt.counts[k][1])
+                }
+                checkOverlay(t, got, tt.a, tt.b, op.op)
+            })
+        }
+    }
+}
+
+func TestOverlaySharedEdgeMerges(t *testing.T) {
+    got := Union(MultiPolygon{box(0, 0, 1, 1)}, MultiPolygon{box(0, 1, 1, 2)})
+    if len(got) != 1 || len(got[0].Outer) != 4 {
+        t.Fatalf("got %v, want a single rectangle without split points", got)
+    }
+    if area, want := planarArea(got[0].Outer), 2.0; math.Abs(area-want) > 1e-12 {
+        t.Errorf("area = %v, want %v", area, want)
+    }
+}
+
+func TestOverlayAntimeridian(t *testing.T) {
+    fiji := MultiPolygon{{Outer: fijiRing, Mode: ContainmentAntimeridian}}
+    // Box from 175°E to 175°W
+    straddle := MultiPolygon{box(-15, 175, -5, 185)}
+    straddle[0].Mode = ContainmentAntimeridian
+    for i := range straddle[0].Outer {
+        straddle[0].Outer[i].Lon = angNormalize(straddle[0].Outer[i].Lon)
+    }
+
+    inter := Intersection(fiji, straddle)
+    if len(inter) != 1 || inter[0].Mode != ContainmentAntimeridian {
+        t.Fatalf("got %v, want one polygon in ContainmentAntimeridian mode", inter)
+    }
+    for _, p := range []Point{{-12, 180}, {-12, -180}, {-12, 177}, {-12, -177}} {
+        if !inter.Contains(p) {
+            t.Errorf("%v not in the intersection", p)
+        }
+    }
+    for _, p := range []Point{{-17, 180}, {-12, 172}, {-12, 0}} {
+        if inter.Contains(p) {
+            t.Errorf("%v in the intersection", p)
+        }
+    }
+    for _, pg := range inter {
+        for _, v := range pg.Outer {
+            if v.Lon < -180 || v.Lon > 180 {
+                t.Errorf("vertex %v not normalized", v)
+            }
+        }
+    }
+
+    union := Union(fiji, straddle)
+    if len(union) != 1 || !union.Contains(Point{-7, -178}) || !union.Contains(Point{-18, 171}) || union.Contains(Point{-7, 171}) {
+        t.Errorf("union: got %v", union)
+    }
+}
+
+func TestOverlaySphericalInput(t *testing.T) {
+    a := MultiPolygon{{Outer: box(0, 0, 2, 2).Outer, Mode: ContainmentSpherical}}
+    b := MultiPolygon{box(1, 1, 3, 3)}
+    for _, got := range []MultiPolygon{Intersection(a, b), Union(a, b), Difference(a, b)} {
+        for _, pg := range got {
+            // Edges were clipped as straight lines, so great-circle mode would misplace them
+            if pg.Mode != ContainmentAntimeridian {
+                t.Errorf("Mode = %v, want ContainmentAntimeridian", pg.Mode)
+            }
+        }
+    }
+    if got := Intersection(MultiPolygon{box(0, 0, 2, 2)}, b); got[0].Mode != ContainmentPlanar {
+        t.Errorf("planar input: Mode = %v, want ContainmentPlanar", got[0].Mode)
+    }
+}
+
+func TestUnionAll(t *testing.T) {
+    tests := []struct {
+        name     string
+        polygons []Polygon
+        members  int
+        holes    int
+    }{
+        {"empty", nil, 0, 0},
+        {"single", []Polygon{box(0, 0, 1, 1)}, 1, 0},
+        {"chain", []Polygon{box(0, 0, 1, 1.5), box(0, 1, 1, 2.5), box(0, 2, 1, 3.5), box(0, 3, 1, 4.5)}, 1, 0},
+        {"tiles", []Polygon{box(0, 0, 1, 1), box(0, 1, 1, 2), box(1, 0, 2, 1), box(1, 1, 2, 2)}, 1, 0},
+        {"ring of boxes", []Polygon{box(0, 0, 1, 3), box(0, 2, 3, 3), box(2, 0, 3, 3), box(0, 0, 3, 1)}, 1, 1},
+        {"disjoint", []Polygon{box(0, 0, 1, 1), box(2, 2, 3, 3), box(4, 4, 5, 5)}, 3, 0},
+        {"duplicates", []Polygon{box(0, 0, 1, 1), box(0, 0, 1, 1), box(0, 0, 1, 1)}, 1, 0},
+        {"nested", []Polygon{box(0, 0, 4, 4), box(1, 1, 2, 2)}, 1, 0},
+        {"clockwise input", []Polygon{{Outer: []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}, box(0.5, 0.5, 1.5, 1.5)}, 1, 0},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := UnionAll(tt.polygons)
+            if len(got) != tt.members || holes(got) != tt.holes {
+                t.Fatalf("got %d polygons with %d holes, want %d with %d", len(got), holes(got), tt.members, tt.holes)
+            }
+            checkOverlay(t, got, MultiPolygon(tt.polygons), nil, func(inA, _ bool) bool { return inA })
+        })
+    }
+}
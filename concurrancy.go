@@ -1,7 +1,7 @@
 package geoutil
 
 import (
-    "sync"
+    "context"
 )
 
 // FullLocation retrieves comprehensive geographic information for a point
@@ -26,66 +26,50 @@ func FullLocation(p Point, geocoder Geocoder, elevation ElevationProvider) (Loca
     return loc, nil
 }
 
+// FullLocationContext retrieves comprehensive geographic information for a point
+// ctx: Context passed to geocoder and elevation provider
+// p: Geographic point
+// geocoder: Context-aware geocoder implementation
+// elevation: Context-aware elevation provider
+// Returns: Complete location information
+func FullLocationContext(ctx context.Context, p Point, geocoder GeocoderContext, elevation ElevationProviderContext) (Location, error) {
+    loc, err := geocoder.ReverseGeocodeContext(ctx, p)
+    if err != nil {
+        return Location{}, err
+    }
+
+    elev, err := elevation.GetElevationContext(ctx, p)
+    if err != nil {
+        return loc, err
+    }
+
+    loc.Elevation = elev
+    // Placeholder for timezone (requires external library)
+    loc.Timezone = "UTC"
+    return loc, nil
+}
+
 // BatchFullLocation retrieves comprehensive geographic information concurrently
 // points: Slice of geographic points
 // geocoder: Geocoder implementation
 // elevation: Elevation provider
-// Returns: Slice of complete location information
+// Returns: Slice of locations (zero for failed items) and *BatchError if any item failed
 func BatchFullLocation(points []Point, geocoder Geocoder, elevation ElevationProvider) ([]Location, error) {
-    type task struct {
-        index int
-        point Point
-    }
-    type result struct {
-        index int
-        loc   Location
-        err   error
-    }
-
-    tasks := make(chan task, len(points))
-    results := make(chan result, len(points)))
-    var wg sync.WaitGroup
-
     // Limit concurrent API requests
-    maxConcurrent := 20
-    sem := make(chan struct{}, maxConcurrent)
-
-    // Start workers
-    for i := 0; i < maxConcurrent; i++ {
-        wg.Add(1)
-        go func() {
-            defer wg.Done()
-            for t := range tasks {
-                sem <- struct{}{}
-                loc, err := FullLocation(t.point, geocoder, elevation)
-                <-sem
-                results <- result{t.index, loc, err}
-            }
-        }()
-    }
-
-    // Send tasks
-    go func() {
-        for i, p := range points {
-            tasks <- task{i, p}
-        }
-        close(tasks)
-    }()
-
-    // Close results when done
-    go func() {
-        wg.Wait()
-        close(results)
-    }()
-
-    // Collect results
-    locations := make([]Location, len(points))
-    for res := range results {
-        if res.err != nil {
-            return nil, res.err
-        }
-        locations[res.index] = res.loc
-    }
+    return runBatch(context.Background(), points, 20, func(_ context.Context, p Point) (Location, error) {
+        return FullLocation(p, geocoder, elevation)
+    })
+}
 
-    return locations, nil
+// BatchFullLocationContext retrieves comprehensive geographic information concurrently
+// ctx: Context controlling all requests; items not started before cancellation fail
+// points: Slice of geographic points
+// geocoder: Context-aware geocoder implementation
+// elevation: Context-aware elevation provider
+// Returns: Slice of locations (zero for failed items) and *BatchError if any item failed
+func BatchFullLocationContext(ctx context.Context, points []Point, geocoder GeocoderContext, elevation ElevationProviderContext) ([]Location, error) {
+    // Limit concurrent API requests
+    return runBatch(ctx, points, 20, func(ctx context.Context, p Point) (Location, error) {
+        return FullLocationContext(ctx, p, geocoder, elevation)
+    })
 }
\ No newline at end of file
@@ -0,0 +1,363 @@
+package geoutil
+
+import "math"
+
+// ContainmentMode selects how polygon edges are interpreted by point-in-polygon tests
+type ContainmentMode int
+
+const (
+    // ContainmentPlanar treats lat/lon as planar coordinates with straight edges (default)
+    // Fast, but wrong for rings crossing the antimeridian or enclosing a pole
+    ContainmentPlanar ContainmentMode = iota
+    // ContainmentAntimeridian is planar with longitudes unwrapped along the ring
+    // Edges take the shorter way across ±180°; rings circling a pole enclose that pole
+    ContainmentAntimeridian
+    // ContainmentSpherical treats edges as great-circle arcs
+    // The ring must fit in the hemisphere centered on its vertices; otherwise
+    // ContainmentAntimeridian is used
+    ContainmentSpherical
+)
+
+// IsPointInPolygonMode determines if a point is inside a polygon ring using the given mode
+// p: Point to check
+// polygon: Vertices of polygon (must have at least 3 points)
+// mode: Edge interpretation
+// Returns: true if point is inside polygon, false otherwise
+func IsPointInPolygonMode(p Point, polygon []Point, mode ContainmentMode) bool {
+    switch mode {
+    case ContainmentAntimeridian:
+        return containsUnwrapped(p, polygon)
+    case ContainmentSpherical:
+        return containsSpherical(p, polygon)
+    default:
+        return IsPointInPolygon(p, polygon)
+    }
+}
+
+// Containment is the position of a point relative to a polygon
+type Containment int
+
+const (
+    Outside    Containment = iota // Outside the polygon area
+    Inside                        // Inside the polygon area, farther than the tolerance from its boundary
+    OnBoundary                    // Within the tolerance of an edge or vertex
+)
+
+// ClassifyPoint locates a point relative to a polygon ring with a boundary tolerance
+// Points on shared borders are reported as OnBoundary for every polygon, so callers
+// can assign them deterministically (e.g. to the lowest id)
+// p: Point to check
+// polygon: Vertices of polygon (must have at least 3 points)
+// tolerance: Boundary half-width in meters; values <= 0 use 1 mm to absorb rounding
+// Returns: Inside, Outside or OnBoundary
+func ClassifyPoint(p Point, polygon []Point, tolerance float64) Containment {
+    return classifyRing(p, polygon, ContainmentPlanar, tolerance)
+}
+
+// Classify locates a point relative to the polygon area with a boundary tolerance
+// Points near a hole's edge are OnBoundary, points inside a hole are Outside
+// p: Point to check
+// tolerance: Boundary half-width in meters; values <= 0 use 1 mm
+// Returns: Inside, Outside or OnBoundary
+func (pg Polygon) Classify(p Point, tolerance float64) Containment {
+    c := classifyRing(p, pg.Outer, pg.Mode, tolerance)
+    if c != Inside {
+        return c
+    }
+    for _, hole := range pg.Holes {
+        switch classifyRing(p, hole, pg.Mode, tolerance) {
+        case OnBoundary:
+            return OnBoundary
+        case Inside:
+            return Outside
+        }
+    }
+    return Inside
+}
+
+// Classify locates a point relative to the multipolygon area with a boundary tolerance
+// Inside any member wins over OnBoundary of another
+// p: Point to check
+// tolerance: Boundary half-width in meters; values <= 0 use 1 mm
+// Returns: Inside, Outside or OnBoundary
+func (mp MultiPolygon) Classify(p Point, tolerance float64) Containment {
+    result := Outside
+    for _, pg := range mp {
+        switch pg.Classify(p, tolerance) {
+        case Inside:
+            return Inside
+        case OnBoundary:
+            result = OnBoundary
+        }
+    }
+    return result
+}
+
+// classifyRing checks the boundary distance before falling back to the containment test
+func classifyRing(p Point, ring []Point, mode ContainmentMode, tolerance float64) Containment {
+    if len(ring) == 0 {
+        return Outside
+    }
+    if tolerance <= 0 {
+        tolerance = 0.001
+    }
+    if ringBoundaryDistance(p, ring, mode) <= tolerance {
+        return OnBoundary
+    }
+    if IsPointInPolygonMode(p, ring, mode) {
+        return Inside
+    }
+    return Outside
+}
+
+// ringBoundaryDistance returns the distance in meters from p to the nearest ring edge
+// Planar edges are measured in an equirectangular frame centered on p, which is
+// accurate at tolerance scales; spherical edges use great-circle geometry
+func ringBoundaryDistance(p Point, ring []Point, mode ContainmentMode) float64 {
+    best := math.Inf(1)
+    if mode == ContainmentSpherical {
+        for i := range ring {
+            _, d := ClosestPointOnSegment(p, ring[i], ring[(i+1)%len(ring)])
+            best = math.Min(best, d*1000)
+        }
+        return best
+    }
+
+    // Meters per degree of latitude, and the longitude scale at p
+    k := EarthSphere.Radius * 1000 * degToRad
+    kx := k * math.Cos(p.Lat*degToRad)
+    for i := range ring {
+        a, b := ring[i], ring[(i+1)%len(ring)]
+        ax, bx := a.Lon-p.Lon, b.Lon-p.Lon
+        if mode == ContainmentAntimeridian {
+            ax = angNormalize(ax)
+            bx = ax + angNormalize(b.Lon-a.Lon)
+        }
+        ax, bx = ax*kx, bx*kx
+        ay, by := (a.Lat-p.Lat)*k, (b.Lat-p.Lat)*k
+
+        // Closest point of segment a-b to the origin
+        dx, dy := bx-ax, by-ay
+        t := 0.0
+        if l2 := dx*dx + dy*dy; l2 > 0 {
+            t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l2))
+        }
+        best = math.Min(best, math.Hypot(ax+t*dx, ay+t*dy))
+    }
+    return best
+}
+
+// containsUnwrapped ray-casts against the ring with continuous longitudes
+func containsUnwrapped(p Point, ring []Point) bool {
+    if len(ring) < 3 {
+        return false
+    }
+
+    u := unwrapRing(ring)
+    if u.pole != 0 && p.Lat == u.pole {
+        return true
+    }
+    // Try every copy of p's longitude that falls inside the unwrapped span
+    lon := p.Lon - 360*math.Ceil((p.Lon-u.minLon)/360)
+    for ; lon <= u.maxLon; lon += 360 {
+        if lon >= u.minLon && IsPointInPolygon(Point{Lat: p.Lat, Lon: lon}, u.points) {
+            return true
+        }
+    }
+    return false
+}
+
+// unwrappedRing is a ring with longitudes made continuous across ±180°
+type unwrappedRing struct {
+    points         []Point
+    minLon, maxLon float64
+    pole           float64 // Latitude of the enclosed pole (±90), 0 if none
+}
+
+// unwrapRing follows the shorter longitude step between vertices
+// A ring winding once around a pole is closed along that pole, chosen
+// on the side of the ring's mean latitude
+func unwrapRing(ring []Point) unwrappedRing {
+    u := unwrappedRing{
+        points: make([]Point, len(ring), len(ring)+3),
+        minLon: ring[0].Lon,
+        maxLon: ring[0].Lon,
+    }
+    u.points[0] = ring[0]
+    meanLat := ring[0].Lat
+    for i := 1; i < len(ring); i++ {
+        lon := u.points[i-1].Lon + angNormalize(ring[i].Lon-ring[i-1].Lon)
+        u.points[i] = Point{Lat: ring[i].Lat, Lon: lon}
+        u.minLon = math.Min(u.minLon, lon)
+        u.maxLon = math.Max(u.maxLon, lon)
+        meanLat += ring[i].Lat
+    }
+
+    last := u.points[len(ring)-1]
+    endLon := last.Lon + angNormalize(ring[0].Lon-last.Lon)
+    if math.Abs(endLon-ring[0].Lon) > 180 {
+        u.pole = 90
+        if meanLat < 0 {
+            u.pole = -90
+        }
+        u.points = append(u.points,
+            Point{Lat: ring[0].Lat, Lon: endLon},
+            Point{Lat: u.pole, Lon: endLon},
+            Point{Lat: u.pole, Lon: ring[0].Lon},
+        )
+        u.minLon = math.Min(u.minLon, endLon)
+        u.maxLon = math.Max(u.maxLon, endLon)
+    }
+    return u
+}
+
+// ringBounds returns a rectangle covering the ring as interpreted by mode
+// Rings crossing the antimeridian get the full longitude range
+func ringBounds(ring []Point, mode ContainmentMode) rect {
+    r := ringRect(ring)
+    if mode == ContainmentPlanar || len(ring) < 3 {
+        return r
+    }
+
+    u := unwrapRing(ring)
+    if u.pole != 0 || u.minLon < -180 || u.maxLon > 180 {
+        r.minLon, r.maxLon = -180, 180
+    }
+    if u.pole > 0 {
+        r.maxLat = 90
+    } else if u.pole < 0 {
+        r.minLat = -90
+    }
+    if mode == ContainmentSpherical {
+        // Great-circle edges can bulge poleward of their endpoints
+        for i := range ring {
+            lo, hi := arcLatRange(ring[i], ring[(i+1)%len(ring)])
+            r.minLat = math.Min(r.minLat, lo)
+            r.maxLat = math.Max(r.maxLat, hi)
+        }
+    }
+    return r
+}
+
+// arcLatRange returns the latitude extent of the shorter great-circle arc from a to b
+func arcLatRange(a, b Point) (float64, float64) {
+    lo, hi := math.Min(a.Lat, b.Lat), math.Max(a.Lat, b.Lat)
+    va, vb := toVec3(a), toVec3(b)
+    n := va.cross(vb)
+    if n.norm() < 1e-12 {
+        return lo, hi
+    }
+    // Northernmost point of the full great circle
+    top := vec3{0, 0, 1}.add(n.scale(-n.z / n.dot(n)))
+    if top.norm() < 1e-12 {
+        // The circle is the equator
+        return lo, hi
+    }
+    top = top.scale(1 / top.norm())
+    for _, v := range []vec3{top, top.scale(-1)} {
+        if va.cross(v).dot(n) >= 0 && v.cross(vb).dot(n) >= 0 {
+            lat := math.Asin(clampUnit(v.z)) * radToDeg
+            lo = math.Min(lo, lat)
+            hi = math.Max(hi, lat)
+        }
+    }
+    return lo, hi
+}
+
+// containsSpherical ray-casts in a gnomonic projection, which maps great circles to lines
+func containsSpherical(p Point, ring []Point) bool {
+    if len(ring) < 3 {
+        return false
+    }
+    f, ok := newGnomonicFrame(ring)
+    if !ok {
+        return containsUnwrapped(p, ring)
+    }
+    q, ok := f.project(p)
+    if !ok {
+        // Every edge lies in the open hemisphere around the center, so p cannot be enclosed
+        return false
+    }
+    return IsPointInPolygon(q, f.ring)
+}
+
+// gnomonicFrame is a tangent plane at the normalized sum of a ring's vertices
+type gnomonicFrame struct {
+    c, e1, e2 vec3    // Center and plane basis
+    ring      []Point // Projected ring with Lon as x and Lat as y
+}
+
+// newGnomonicFrame projects a ring onto the plane tangent at its center
+// Returns: false if the ring does not fit in the open hemisphere around the center
+func newGnomonicFrame(ring []Point) (gnomonicFrame, bool) {
+    var f gnomonicFrame
+    verts := make([]vec3, len(ring))
+    for i, v := range ring {
+        verts[i] = toVec3(v)
+        f.c = f.c.add(verts[i])
+    }
+    if f.c.norm() < 1e-12 {
+        return f, false
+    }
+    f.c = f.c.scale(1 / f.c.norm())
+
+    f.e1 = vec3{0, 0, 1}.cross(f.c)
+    if f.e1.norm() < 1e-12 {
+        f.e1 = vec3{0, 1, 0}
+    }
+    f.e1 = f.e1.scale(1 / f.e1.norm())
+    f.e2 = f.c.cross(f.e1)
+
+    f.ring = make([]Point, len(verts))
+    for i, v := range verts {
+        d := v.dot(f.c)
+        if d < 1e-9 {
+            return f, false
+        }
+        f.ring[i] = Point{Lat: v.dot(f.e2) / d, Lon: v.dot(f.e1) / d}
+    }
+    return f, true
+}
+
+// project maps p onto the plane
+// Returns: false if p is in the opposite hemisphere
+func (f gnomonicFrame) project(p Point) (Point, bool) {
+    q := toVec3(p)
+    d := q.dot(f.c)
+    if d <= 0 {
+        return Point{}, false
+    }
+    return Point{Lat: q.dot(f.e2) / d, Lon: q.dot(f.e1) / d}, true
+}
+
+// vec3 is a point on the unit sphere in Earth-centered coordinates
+type vec3 struct {
+    x, y, z float64
+}
+
+// toVec3 converts a geographic point to a unit vector
+func toVec3(p Point) vec3 {
+    sinφ, cosφ := math.Sincos(p.Lat * degToRad)
+    sinλ, cosλ := math.Sincos(p.Lon * degToRad)
+    return vec3{cosφ * cosλ, cosφ * sinλ, sinφ}
+}
+
+func (a vec3) add(b vec3) vec3 {
+    return vec3{a.x + b.x, a.y + b.y, a.z + b.z}
+}
+
+func (a vec3) scale(s float64) vec3 {
+    return vec3{a.x * s, a.y * s, a.z * s}
+}
+
+func (a vec3) dot(b vec3) float64 {
+    return a.x*b.x + a.y*b.y + a.z*b.z
+}
+
+func (a vec3) cross(b vec3) vec3 {
+    return vec3{a.y*b.z - a.z*b.y, a.z*b.x - a.x*b.z, a.x*b.y - a.y*b.x}
+}
+
+func (a vec3) norm() float64 {
+    return math.Sqrt(a.dot(a))
+}
\ No newline at end of file
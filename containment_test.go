@@ -0,0 +1,121 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// Ring crossing the antimeridian, e.g. around Fiji
+var fijiRing = []Point{{-20, 170}, {-20, -170}, {-10, -170}, {-10, 170}}
+
+// Rings circling the poles at a constant latitude
+var (
+    arcticRing    = []Point{{80, 0}, {80, 90}, {80, 180}, {80, -90}}
+    antarcticRing = []Point{{-70, 0}, {-70, -90}, {-70, 180}, {-70, 90}}
+)
+
+func TestIsPointInPolygonModeAntimeridian(t *testing.T) {
+    tests := []struct {
+        name string
+        p    Point
+        mode ContainmentMode
+        want bool
+    }{
+        {"lon 180", Point{-15, 180}, ContainmentAntimeridian, true},
+        {"lon -180", Point{-15, -180}, ContainmentAntimeridian, true},
+        {"east of 180", Point{-15, -175}, ContainmentAntimeridian, true},
+        {"west of 180", Point{-15, 175}, ContainmentAntimeridian, true},
+        {"greenwich", Point{-15, 0}, ContainmentAntimeridian, false},
+        {"west of ring", Point{-15, 160}, ContainmentAntimeridian, false},
+        {"north of ring at 180", Point{-5, 180}, ContainmentAntimeridian, false},
+        {"spherical lon 180", Point{-15, 180}, ContainmentSpherical, true},
+        {"spherical lon -180", Point{-15, -180}, ContainmentSpherical, true},
+        {"spherical greenwich", Point{-15, 0}, ContainmentSpherical, false},
+        // Planar mode spans the long way round through Greenwich
+        {"planar greenwich", Point{-15, 0}, ContainmentPlanar, true},
+        {"planar lon 180", Point{-15, 180}, ContainmentPlanar, false},
+        // The southern edge bulges to about -20.28° at 180 as a great circle
+        {"spherical edge bulge", Point{-20.1, 180}, ContainmentSpherical, true},
+        {"unwrapped straight edge", Point{-20.1, 180}, ContainmentAntimeridian, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := IsPointInPolygonMode(tt.p, fijiRing, tt.mode); got != tt.want {
+                t.Errorf("IsPointInPolygonMode(%v) = %v, want %v", tt.p, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestIsPointInPolygonModePolarCap(t *testing.T) {
+    tests := []struct {
+        name string
+        p    Point
+        ring []Point
+        mode ContainmentMode
+        want bool
+    }{
+        {"north pole", Point{90, 0}, arcticRing, ContainmentAntimeridian, true},
+        {"inside cap", Point{85, 45}, arcticRing, ContainmentAntimeridian, true},
+        {"inside cap at 180", Point{85, 180}, arcticRing, ContainmentAntimeridian, true},
+        {"inside cap at -180", Point{85, -180}, arcticRing, ContainmentAntimeridian, true},
+        {"below cap", Point{75, 0}, arcticRing, ContainmentAntimeridian, false},
+        {"south pole", Point{-90, 0}, arcticRing, ContainmentAntimeridian, false},
+        {"spherical north pole", Point{90, 0}, arcticRing, ContainmentSpherical, true},
+        {"spherical inside cap", Point{85, -135}, arcticRing, ContainmentSpherical, true},
+        {"spherical below cap", Point{75, 0}, arcticRing, ContainmentSpherical, false},
+        // Great-circle edges between 80° vertices rise to about 82.9° midway
+        {"below great-circle edge", Point{81, 45}, arcticRing, ContainmentSpherical, false},
+        {"above parallel edge", Point{81, 45}, arcticRing, ContainmentAntimeridian, true},
+        {"antarctic south pole", Point{-90, 0}, antarcticRing, ContainmentAntimeridian, true},
+        {"antarctic inside", Point{-80, 10}, antarcticRing, ContainmentAntimeridian, true},
+        {"antarctic at 180", Point{-75, 180}, antarcticRing, ContainmentAntimeridian, true},
+        {"antarctic outside", Point{-60, 0}, antarcticRing, ContainmentAntimeridian, false},
+        {"antarctic spherical", Point{-80, 10}, antarcticRing, ContainmentSpherical, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := IsPointInPolygonMode(tt.p, tt.ring, tt.mode); got != tt.want {
+                t.Errorf("IsPointInPolygonMode(%v) = %v, want %v", tt.p, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestArcLatRange(t *testing.T) {
+    // Vertex latitude of the great circle through (±45, 0) and (±45, 90)
+    vertex := math.Atan(math.Sqrt2) * radToDeg
+    tests := []struct {
+        name   string
+        a, b   Point
+        lo, hi float64
+    }{
+        {"northern bulge", Point{45, 0}, Point{45, 90}, 45, vertex},
+        {"southern bulge", Point{-45, 0}, Point{-45, 90}, -vertex, -45},
+        {"across antimeridian", Point{45, 135}, Point{45, -135}, 45, vertex},
+        {"over the pole", Point{80, 0}, Point{80, 180}, 80, 90},
+        {"equator", Point{0, 0}, Point{0, 90}, 0, 0},
+        {"meridian", Point{10, 0}, Point{50, 0}, 10, 50},
+        {"vertex beyond arc", Point{10, 0}, Point{20, 10}, 10, 20},
+        {"same point", Point{30, 30}, Point{30, 30}, 30, 30},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            lo, hi := arcLatRange(tt.a, tt.b)
+            if math.Abs(lo-tt.lo) > 1e-9 || math.Abs(hi-tt.hi) > 1e-9 {
+                t.Errorf("arcLatRange(%v, %v) = (%v, %v), want (%v, %v)", tt.a, tt.b, lo, hi, tt.lo, tt.hi)
+            }
+        })
+    }
+}
+
+func TestRingBoundsCrossingAntimeridian(t *testing.T) {
+    r := ringBounds(fijiRing, ContainmentAntimeridian)
+    if r.minLon != -180 || r.maxLon != 180 {
+        t.Errorf("longitude range = [%v, %v], want [-180, 180]", r.minLon, r.maxLon)
+    }
+    r = ringBounds(arcticRing, ContainmentSpherical)
+    if r.maxLat != 90 || r.minLat != 80 {
+        t.Errorf("latitude range = [%v, %v], want [80, 90]", r.minLat, r.maxLat)
+    }
+}
@@ -0,0 +1,371 @@
+package geoutil
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+    "unicode"
+)
+
+// ErrCoordinateSyntax is returned when a coordinate string cannot be parsed
+var ErrCoordinateSyntax = errors.New("invalid coordinate syntax")
+
+// CoordinateFormat selects the notation used by Point.Format
+type CoordinateFormat int
+
+const (
+    // FormatDecimal writes signed decimal degrees, e.g. 40.446, -79.982
+    FormatDecimal CoordinateFormat = iota
+    // FormatDMS writes degrees, minutes and seconds, e.g. 40°26'46"N 79°58'56"W
+    FormatDMS
+    // FormatDDM writes degrees and decimal minutes, e.g. 40°26.767'N 79°58.933'W
+    FormatDDM
+)
+
+// coordToken is a number, hemisphere letter or separator found by tokenizeCoordinate
+type coordToken struct {
+    kind     byte    // 'n' number, 'h' hemisphere, ',' separator
+    value    float64 // Absolute value of a number
+    negative bool    // Number had a minus sign
+    fraction bool    // Number had a decimal point
+    unit     int     // 0 none, 1 degrees, 2 minutes, 3 seconds
+    hemi     rune    // N, S, E or W
+}
+
+// coordGroup is one latitude or longitude value
+type coordGroup struct {
+    numbers []coordToken
+    hemi    rune
+}
+
+// ParsePoint parses a latitude/longitude pair in decimal, DMS or DDM notation
+// Accepted forms include 40.446, -79.982 and 40°26'46"N 79°58'56"W and N40 26.767 W79 58.933;
+// hemisphere letters may precede or follow a value and put longitude first,
+// and values may be separated by spaces, commas, semicolons or slashes;
+// a value with both a minus sign and a hemisphere letter is rejected as ambiguous
+// s: Coordinate string
+// Returns: Point, ErrCoordinateSyntax for malformed input or ErrInvalidCoordinate for out-of-range values
+func ParsePoint(s string) (Point, error) {
+    groups, err := parseCoordGroups(s)
+    if err != nil {
+        return Point{}, err
+    }
+    // Without hemispheres or separators, "40 26 46 79 58 56" splits in the middle
+    if len(groups) == 1 && groups[0].hemi == 0 && len(groups[0].numbers)%2 == 0 {
+        g, half := groups[0], len(groups[0].numbers)/2
+        groups = []coordGroup{{numbers: g.numbers[:half]}, {numbers: g.numbers[half:]}}
+    }
+    if len(groups) != 2 {
+        return Point{}, fmt.Errorf("%w %q: expected latitude and longitude", ErrCoordinateSyntax, s)
+    }
+
+    first, err := groups[0].degrees()
+    if err != nil {
+        return Point{}, fmt.Errorf("%w %q: %s", ErrCoordinateSyntax, s, err)
+    }
+    second, err := groups[1].degrees()
+    if err != nil {
+        return Point{}, fmt.Errorf("%w %q: %s", ErrCoordinateSyntax, s, err)
+    }
+    if groups[0].isLat() && groups[1].isLat() || groups[0].isLon() && groups[1].isLon() {
+        return Point{}, fmt.Errorf("%w %q: both values on the same axis", ErrCoordinateSyntax, s)
+    }
+
+    p := Point{Lat: first, Lon: second}
+    if groups[0].isLon() || groups[1].isLat() {
+        p = Point{Lat: second, Lon: first}
+    }
+    if err := p.Validate(); err != nil {
+        return Point{}, err
+    }
+    return p, nil
+}
+
+// ParseCoordinate parses a single latitude or longitude, e.g. from a separate CSV column
+// Accepts the same notations as ParsePoint
+// s: Coordinate string such as 79°58'56"W or -79.982
+// Returns: Signed degrees, ErrCoordinateSyntax or ErrInvalidCoordinate for values out of range
+func ParseCoordinate(s string) (float64, error) {
+    groups, err := parseCoordGroups(s)
+    if err != nil {
+        return 0, err
+    }
+    if len(groups) != 1 {
+        return 0, fmt.Errorf("%w %q: expected a single value", ErrCoordinateSyntax, s)
+    }
+    v, err := groups[0].degrees()
+    if err != nil {
+        return 0, fmt.Errorf("%w %q: %s", ErrCoordinateSyntax, s, err)
+    }
+    limit := 180.0
+    if groups[0].isLat() {
+        limit = 90
+    }
+    if math.Abs(v) > limit {
+        return 0, fmt.Errorf("%w: %q exceeds %g degrees", ErrInvalidCoordinate, s, limit)
+    }
+    return v, nil
+}
+
+// Format writes the point in the given notation
+// format: FormatDecimal, FormatDMS or FormatDDM
+// precision: Decimal places of the degrees, seconds or minutes respectively; -1 for full precision
+// Returns: Latitude followed by longitude, which ParsePoint reads back
+func (p Point) Format(format CoordinateFormat, precision int) string {
+    switch format {
+    case FormatDMS, FormatDDM:
+        return formatAngle(p.Lat, 'N', 'S', format, precision) + " " + formatAngle(p.Lon, 'E', 'W', format, precision)
+    }
+    return formatDecimal(p.Lat, precision) + ", " + formatDecimal(p.Lon, precision)
+}
+
+// formatDecimal writes v with the given decimal places, printing values that round
+// to zero without a minus sign
+func formatDecimal(v float64, precision int) string {
+    s := strconv.FormatFloat(v, 'f', precision, 64)
+    if strings.Trim(s, "-0.") == "" {
+        return strings.TrimPrefix(s, "-")
+    }
+    return s
+}
+
+// formatAngle writes an absolute angle in DMS or DDM followed by its hemisphere letter
+func formatAngle(v float64, pos, neg byte, format CoordinateFormat, precision int) string {
+    hemi := pos
+    if v < 0 {
+        hemi = neg
+    }
+    v = math.Abs(v)
+
+    // Full precision keeps the decimal places of v, which a multiple of v by 60 or
+    // 3600 never exceeds; trailing zeros are trimmed from the last part
+    trim := precision < 0
+    if trim {
+        precision = min(decimalPlaces(v), 17)
+    }
+
+    // Split into whole degrees and minutes and a fractional last part; rounding first
+    // carries 59.9996" up to the next minute instead of printing 60"
+    parts := 60.0
+    if format == FormatDMS {
+        parts = 3600
+    }
+    scale := math.Pow(10, float64(precision))
+    total := math.Round(v*parts*scale) / scale
+    if total == 0 {
+        hemi = pos
+    }
+    deg := math.Floor(total / parts)
+    rest := total - deg*parts
+
+    // The subtractions leave rounding noise below the last kept decimal
+    last := func(x float64) string {
+        s := strconv.FormatFloat(x, 'f', precision, 64)
+        if trim && strings.Contains(s, ".") {
+            s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+        }
+        return s
+    }
+
+    var b strings.Builder
+    b.WriteString(strconv.FormatFloat(deg, 'f', 0, 64))
+    b.WriteString("°")
+    if format == FormatDMS {
+        mins := math.Floor(rest / 60)
+        b.WriteString(strconv.FormatFloat(mins, 'f', 0, 64))
+        b.WriteString("'")
+        b.WriteString(last(rest - mins*60))
+        b.WriteString(`"`)
+    } else {
+        b.WriteString(last(rest))
+        b.WriteString("'")
+    }
+    b.WriteByte(hemi)
+    return b.String()
+}
+
+// decimalPlaces counts the digits after the decimal point in the shortest representation of v
+func decimalPlaces(v float64) int {
+    s := strconv.FormatFloat(v, 'f', -1, 64)
+    if i := strings.IndexByte(s, '.'); i >= 0 {
+        return len(s) - i - 1
+    }
+    return 0
+}
+
+// parseCoordGroups tokenizes s and splits it into values at hemisphere letters,
+// separators and degree signs
+func parseCoordGroups(s string) ([]coordGroup, error) {
+    tokens, err := tokenizeCoordinate(s)
+    if err != nil {
+        return nil, err
+    }
+    if len(tokens) == 0 {
+        return nil, fmt.Errorf("%w %q: empty", ErrCoordinateSyntax, s)
+    }
+
+    // Hemisphere letters either all precede (N40 26.767) or all follow (40°26'46"N) their values
+    prefix := tokens[0].kind == 'h'
+    var groups []coordGroup
+    var cur coordGroup
+    flush := func() {
+        if len(cur.numbers) > 0 || cur.hemi != 0 {
+            groups = append(groups, cur)
+        }
+        cur = coordGroup{}
+    }
+    for _, t := range tokens {
+        switch t.kind {
+        case ',':
+            if len(cur.numbers) == 0 && (prefix || cur.hemi != 0) {
+                return nil, fmt.Errorf("%w %q: separator without a value", ErrCoordinateSyntax, s)
+            }
+            flush()
+        case 'h':
+            if prefix {
+                flush()
+                cur.hemi = t.hemi
+                continue
+            }
+            if len(cur.numbers) == 0 {
+                return nil, fmt.Errorf("%w %q: hemisphere %c without a value", ErrCoordinateSyntax, s, t.hemi)
+            }
+            cur.hemi = t.hemi
+            flush()
+        case 'n':
+            if t.unit == 1 && len(cur.numbers) > 0 {
+                flush()
+            }
+            cur.numbers = append(cur.numbers, t)
+        }
+    }
+    flush()
+
+    for _, g := range groups {
+        if len(g.numbers) == 0 {
+            return nil, fmt.Errorf("%w %q: hemisphere %c without a value", ErrCoordinateSyntax, s, g.hemi)
+        }
+    }
+    return groups, nil
+}
+
+// tokenizeCoordinate splits s into numbers with their unit marks, hemisphere letters
+// and separators; spaces and colons only delimit numbers
+func tokenizeCoordinate(s string) ([]coordToken, error) {
+    var tokens []coordToken
+    runes := []rune(s)
+    // mark attaches a unit to the number just read
+    mark := func(r rune, unit int) error {
+        if len(tokens) == 0 || tokens[len(tokens)-1].kind != 'n' || tokens[len(tokens)-1].unit != 0 {
+            return fmt.Errorf("%w %q: unexpected %q", ErrCoordinateSyntax, s, r)
+        }
+        tokens[len(tokens)-1].unit = unit
+        return nil
+    }
+
+    for i := 0; i < len(runes); i++ {
+        r := runes[i]
+        switch {
+        case unicode.IsDigit(r) || r == '.' || r == '-' || r == '+' || r == '−':
+            t := coordToken{kind: 'n', negative: r == '-' || r == '−'}
+            if r == '-' || r == '+' || r == '−' {
+                i++
+            }
+            start := i
+            for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+                t.fraction = t.fraction || runes[i] == '.'
+                i++
+            }
+            v, err := strconv.ParseFloat(string(runes[start:i]), 64)
+            if err != nil {
+                return nil, fmt.Errorf("%w %q: bad number %q", ErrCoordinateSyntax, s, string(runes[start:i]))
+            }
+            t.value = v
+            tokens = append(tokens, t)
+            i--
+        case r == '°' || r == 'º' || r == '˚':
+            if err := mark(r, 1); err != nil {
+                return nil, err
+            }
+        case r == '\'' || r == '′' || r == '’' || r == '‘':
+            // Two apostrophes are a common stand-in for a double quote
+            if r == '\'' && i+1 < len(runes) && runes[i+1] == '\'' {
+                i++
+                if err := mark('"', 3); err != nil {
+                    return nil, err
+                }
+                continue
+            }
+            if err := mark(r, 2); err != nil {
+                return nil, err
+            }
+        case r == '"' || r == '″' || r == '”' || r == '“':
+            if err := mark(r, 3); err != nil {
+                return nil, err
+            }
+        case strings.ContainsRune("NSEWnsew", r):
+            tokens = append(tokens, coordToken{kind: 'h', hemi: unicode.ToUpper(r)})
+        case r == ',' || r == ';' || r == '/':
+            tokens = append(tokens, coordToken{kind: ','})
+        case unicode.IsSpace(r) || r == ':':
+        default:
+            return nil, fmt.Errorf("%w %q: unexpected %q", ErrCoordinateSyntax, s, r)
+        }
+    }
+    return tokens, nil
+}
+
+// degrees combines the group's numbers into signed decimal degrees
+func (g coordGroup) degrees() (float64, error) {
+    if len(g.numbers) > 3 {
+        return 0, errors.New("too many numbers in one value")
+    }
+    var parts [3]float64
+    next := 0
+    for i, t := range g.numbers {
+        pos := next
+        if t.unit > 0 {
+            pos = t.unit - 1
+        }
+        if pos < next || pos > 2 {
+            return 0, errors.New("units out of order")
+        }
+        if i > 0 && t.negative {
+            return 0, errors.New("sign on minutes or seconds")
+        }
+        if t.fraction && i < len(g.numbers)-1 {
+            return 0, errors.New("fraction before the last part")
+        }
+        if pos > 0 && t.value >= 60 {
+            return 0, errors.New("minutes or seconds of 60 or more")
+        }
+        parts[pos] = t.value
+        next = pos + 1
+    }
+
+    v := parts[0] + parts[1]/60 + parts[2]/3600
+    negative := g.numbers[0].negative
+    // -79.982E could mean east or west, so a sign and a letter never combine
+    if negative && g.hemi != 0 {
+        return 0, errors.New("both a minus sign and a hemisphere")
+    }
+    if g.hemi == 'S' || g.hemi == 'W' {
+        negative = true
+    }
+    if negative {
+        v = -v
+    }
+    return v, nil
+}
+
+// isLat reports whether the group has a N or S hemisphere letter
+func (g coordGroup) isLat() bool {
+    return g.hemi == 'N' || g.hemi == 'S'
+}
+
+// isLon reports whether the group has an E or W hemisphere letter
+func (g coordGroup) isLon() bool {
+    return g.hemi == 'E' || g.hemi == 'W'
+}
\ No newline at end of file
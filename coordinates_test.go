@@ -0,0 +1,152 @@
+package geoutil
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+func TestPointFormat(t *testing.T) {
+    tests := []struct {
+        p         Point
+        format    CoordinateFormat
+        precision int
+        want      string
+    }{
+        {Point{40.446, -79.982}, FormatDMS, -1, `40°26'45.6"N 79°58'55.2"W`},
+        {Point{40.446, -79.982}, FormatDDM, -1, `40°26.76'N 79°58.92'W`},
+        {Point{40.446, -79.982}, FormatDMS, 0, `40°26'46"N 79°58'55"W`},
+        {Point{40.446, -79.982}, FormatDDM, 3, `40°26.760'N 79°58.920'W`},
+        {Point{40.446, -79.982}, FormatDecimal, -1, "40.446, -79.982"},
+        {Point{45, 10}, FormatDMS, -1, `45°0'0"N 10°0'0"E`},
+        {Point{59.99999999, 0}, FormatDMS, 2, `60°0'0.00"N 0°0'0.00"E`},
+        {Point{-0.000001, -0.000001}, FormatDMS, 1, `0°0'0.0"N 0°0'0.0"E`},
+        {Point{-0.000001, -0.000001}, FormatDecimal, 2, "0.00, 0.00"},
+        {Point{-0.001, 0.001}, FormatDecimal, 2, "0.00, 0.00"},
+        {Point{-0.006, 0}, FormatDecimal, 2, "-0.01, 0.00"},
+    }
+    for _, tt := range tests {
+        got := tt.p.Format(tt.format, tt.precision)
+        if got != tt.want {
+            t.Errorf("%v.Format(%v, %d) = %s, want %s", tt.p, tt.format, tt.precision, got, tt.want)
+            continue
+        }
+        q, err := ParsePoint(got)
+        if err != nil {
+            t.Errorf("ParsePoint(%s): %v", got, err)
+        } else if tt.precision < 0 && !q.Equal(tt.p, 1e-6) {
+            t.Errorf("ParsePoint(%s) = %v, want %v", got, q, tt.p)
+        }
+    }
+}
+
+func TestParsePoint(t *testing.T) {
+    pittsburgh := Point{40 + 26.0/60 + 46.0/3600, -(79 + 58.0/60 + 56.0/3600)}
+    tests := []struct {
+        in      string
+        want    Point
+        wantErr error
+    }{
+        {`40°26'46"N 79°58'56"W`, pittsburgh, nil},
+        {`40°26'46''N, 79°58'56''W`, pittsburgh, nil},
+        {"N40 26.767 W79 58.933", Point{40 + 26.767/60, -(79 + 58.933/60)}, nil},
+        {"40.446, -79.982", Point{40.446, -79.982}, nil},
+        {"40.446 -79.982", Point{40.446, -79.982}, nil},
+        {"40.446;-79.982", Point{40.446, -79.982}, nil},
+        {"40 26 46 -79 58 56", pittsburgh, nil},
+        // Longitude first, told apart by hemisphere letters
+        {`79°58'56"W 40°26'46"N`, pittsburgh, nil},
+        {"W79 58.933 N40 26.767", Point{40 + 26.767/60, -(79 + 58.933/60)}, nil},
+        {"79.982W, 40.446N", Point{40.446, -79.982}, nil},
+        {"79.982W 40.446", Point{40.446, -79.982}, nil},
+        // Suffix hemispheres
+        {"33.8688S 151.2093E", Point{-33.8688, 151.2093}, nil},
+        {"33.8688s, 151.2093e", Point{-33.8688, 151.2093}, nil},
+        {"0N 0E", Point{0, 0}, nil},
+
+        {"91, 0", Point{}, ErrInvalidCoordinate},
+        {"0, 180.5", Point{}, ErrInvalidCoordinate},
+        {"91N 0E", Point{}, ErrInvalidCoordinate},
+        {"", Point{}, ErrCoordinateSyntax},
+        {"   ", Point{}, ErrCoordinateSyntax},
+        {"40.446", Point{}, ErrCoordinateSyntax},
+        {"1, 2, 3", Point{}, ErrCoordinateSyntax},
+        {"40N 79W 10E", Point{}, ErrCoordinateSyntax},
+        {"40 60 0N 79 0 0W", Point{}, ErrCoordinateSyntax},
+        {`40°26'60"N 79°58'56"W`, Point{}, ErrCoordinateSyntax},
+        {"N40 75.5 W79 58.933", Point{}, ErrCoordinateSyntax},
+        {"40N 79S", Point{}, ErrCoordinateSyntax},
+        {"40.4x, -79.9", Point{}, ErrCoordinateSyntax},
+        // A minus sign with a hemisphere letter is ambiguous
+        {"-79.982E 40", Point{}, ErrCoordinateSyntax},
+        {"-40.446N 79.982W", Point{}, ErrCoordinateSyntax},
+        {"40.446N -79.982W", Point{}, ErrCoordinateSyntax},
+        {"S-33.8688 E151.2093", Point{}, ErrCoordinateSyntax},
+    }
+    for _, tt := range tests {
+        t.Run(tt.in, func(t *testing.T) {
+            got, err := ParsePoint(tt.in)
+            if tt.wantErr != nil {
+                if !errors.Is(err, tt.wantErr) {
+                    t.Errorf("ParsePoint(%q) = %v, %v; want %v", tt.in, got, err, tt.wantErr)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("ParsePoint(%q): %v", tt.in, err)
+            }
+            if !got.Equal(tt.want, 1e-9) {
+                t.Errorf("ParsePoint(%q) = %v, want %v", tt.in, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestParseCoordinate(t *testing.T) {
+    tests := []struct {
+        in      string
+        want    float64
+        wantErr error
+    }{
+        {`79°58'56"W`, -(79 + 58.0/60 + 56.0/3600), nil},
+        {"W79 58.933", -(79 + 58.933/60), nil},
+        {"-79.982", -79.982, nil},
+        {"+79.982", 79.982, nil},
+        {"79.982", 79.982, nil},
+        {"45.5N", 45.5, nil},
+        {"45 30S", -45.5, nil},
+        {"179.9E", 179.9, nil},
+        {"180", 180, nil},
+
+        {"180.1", 0, ErrInvalidCoordinate},
+        {"90.5N", 0, ErrInvalidCoordinate},
+        {"100S", 0, ErrInvalidCoordinate},
+        {"", 0, ErrCoordinateSyntax},
+        {"N", 0, ErrCoordinateSyntax},
+        {"40, 79", 0, ErrCoordinateSyntax},
+        {"40N 79W", 0, ErrCoordinateSyntax},
+        {"40 26 46 12", 0, ErrCoordinateSyntax},
+        {"40 60", 0, ErrCoordinateSyntax},
+        {"40 26.5 30", 0, ErrCoordinateSyntax},
+        {"40 -26", 0, ErrCoordinateSyntax},
+        {"-79.982E", 0, ErrCoordinateSyntax},
+        {"-45S", 0, ErrCoordinateSyntax},
+    }
+    for _, tt := range tests {
+        t.Run(tt.in, func(t *testing.T) {
+            got, err := ParseCoordinate(tt.in)
+            if tt.wantErr != nil {
+                if !errors.Is(err, tt.wantErr) {
+                    t.Errorf("ParseCoordinate(%q) = %v, %v; want %v", tt.in, got, err, tt.wantErr)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("ParseCoordinate(%q): %v", tt.in, err)
+            }
+            if math.Abs(got-tt.want) > 1e-12 {
+                t.Errorf("ParseCoordinate(%q) = %v, want %v", tt.in, got, tt.want)
+            }
+        })
+    }
+}
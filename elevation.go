@@ -21,6 +21,8 @@ type OpenElevationProvider struct {
     cache      *Cache
 }
 
+var _ ElevationProviderContext = (*OpenElevationProvider)(nil)
+
 // NewOpenElevationProvider creates an elevation provider instance
 // rps: Requests per second limit
 func NewOpenElevationProvider(rps int) *OpenElevationProvider {
@@ -36,24 +38,54 @@ func NewOpenElevationProvider(rps int) *OpenElevationProvider {
     }
 }
 
+// elevationWaitTimeout bounds the rate-limiter wait of the methods without a context
+const elevationWaitTimeout = 5 * time.Second
+
 // GetElevation retrieves elevation for a geographic point
+// Waits at most 5 seconds for the rate limiter
 // p: Geographic point
 // Returns: Elevation in meters or error
 func (o *OpenElevationProvider) GetElevation(p Point) (int, error) {
+    return o.getElevation(context.Background(), p, elevationWaitTimeout)
+}
+
+// GetElevationContext retrieves elevation for a geographic point
+// ctx: Context controlling rate-limiter wait and HTTP request
+// p: Geographic point
+// Returns: Elevation in meters, or ErrInvalidCoordinate without a request for invalid points
+func (o *OpenElevationProvider) GetElevationContext(ctx context.Context, p Point) (int, error) {
+    return o.getElevation(ctx, p, 0)
+}
+
+// getElevation retrieves elevation, bounding the rate-limiter wait by waitTimeout if positive
+func (o *OpenElevationProvider) getElevation(ctx context.Context, p Point, waitTimeout time.Duration) (int, error) {
+    if err := p.Validate(); err != nil {
+        return 0, err
+    }
     cacheKey := fmt.Sprintf("elevation_%f_%f", p.Lat, p.Lon)
     if val, found := o.cache.Get(cacheKey); found {
         return val.(int), nil
     }
 
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-    defer cancel()
-    if err := o.limiter.Wait(ctx); err != nil {
+    waitCtx := ctx
+    if waitTimeout > 0 {
+        var cancel context.CancelFunc
+        waitCtx, cancel = context.WithTimeout(ctx, waitTimeout)
+        defer cancel()
+    }
+    if err := o.limiter.Wait(waitCtx); err != nil {
         return 0, err
     }
 
     // Build request body
     body := fmt.Sprintf(`{"locations":[{"latitude":%f,"longitude":%f}]}`, p.Lat, p.Lon)
-    resp, err := o.httpClient.Post(o.baseURL, "application/json", strings.NewReader(body))
+    req, err := http.NewRequestWithContext(ctx, "POST", o.baseURL, strings.NewReader(body))
+    if err != nil {
+        return 0, err
+    }
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := o.httpClient.Do(req)
     if err != nil {
         return 0, err
     }
@@ -79,63 +111,19 @@ func (o *OpenElevationProvider) GetElevation(p Point) (int, error) {
 }
 
 // BatchGetElevation retrieves elevations for multiple points concurrently
+// Each item waits at most 5 seconds for the rate limiter
 // points: Slice of geographic points
-// Returns: Slice of elevations or first error encountered
+// Returns: Slice of elevations (zero for failed items) and *BatchError if any item failed
 func (o *OpenElevationProvider) BatchGetElevation(points []Point) ([]int, error) {
-    type task struct {
-        index int
-        point Point
-    }
-    type result struct {
-        index int
-        value int
-        err   error
-    }
-
-    tasks := make(chan task, len(points))
-    results := make(chan result, len(points)))
-    var wg sync.WaitGroup
-
-    // Determine optimal worker count
-    numWorkers := 8
-    if numWorkers > len(points) {
-        numWorkers = len(points)
-    }
-
-    // Start workers
-    for i := 0; i < numWorkers; i++ {
-        wg.Add(1)
-        go func() {
-            defer wg.Done()
-            for t := range tasks {
-                elev, err := o.GetElevation(t.point)
-                results <- result{t.index, elev, err}
-            }
-        }()
-    }
-
-    // Send tasks
-    go func() {
-        for i, p := range points {
-            tasks <- task{i, p}
-        }
-        close(tasks)
-    }()
-
-    // Close results channel when done
-    go func() {
-        wg.Wait()
-        close(results)
-    }()
-
-    // Collect results
-    elevations := make([]int, len(points))
-    for res := range results {
-        if res.err != nil {
-            return nil, res.err
-        }
-        elevations[res.index] = res.value
-    }
+    return runBatch(context.Background(), points, 8, func(ctx context.Context, p Point) (int, error) {
+        return o.getElevation(ctx, p, elevationWaitTimeout)
+    })
+}
 
-    return elevations, nil
+// BatchGetElevationContext retrieves elevations for multiple points concurrently
+// ctx: Context controlling all requests; items not started before cancellation fail
+// points: Slice of geographic points
+// Returns: Slice of elevations (zero for failed items) and *BatchError if any item failed
+func (o *OpenElevationProvider) BatchGetElevationContext(ctx context.Context, points []Point) ([]int, error) {
+    return runBatch(ctx, points, 8, o.GetElevationContext)
 }
\ No newline at end of file
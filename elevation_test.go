@@ -0,0 +1,52 @@
+package geoutil
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "golang.org/x/time/rate"
+)
+
+// saturatedElevationProvider returns a provider whose next token is an hour away
+func saturatedElevationProvider() *OpenElevationProvider {
+    o := NewOpenElevationProvider(1)
+    o.baseURL = "http://127.0.0.1:0"
+    o.limiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+    o.limiter.Allow()
+    return o
+}
+
+func TestElevationLimiterWait(t *testing.T) {
+    o := saturatedElevationProvider()
+    p := Point{46.5, 7.9}
+
+    // Without a context the wait is bounded, so the call fails instead of blocking
+    done := make(chan error, 1)
+    go func() {
+        _, err := o.GetElevation(p)
+        done <- err
+    }()
+    select {
+    case err := <-done:
+        if err == nil {
+            t.Fatal("GetElevation succeeded with a saturated limiter")
+        }
+    case <-time.After(elevationWaitTimeout + time.Second):
+        t.Fatal("GetElevation blocked past its wait timeout")
+    }
+
+    _, err := o.BatchGetElevation([]Point{p, p})
+    var batchErr *BatchError
+    if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 {
+        t.Fatalf("BatchGetElevation error = %v, want both items failed", err)
+    }
+
+    // With a context the caller's deadline alone bounds the wait
+    ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+    defer cancel()
+    if _, err := o.GetElevationContext(ctx, p); err == nil {
+        t.Fatal("GetElevationContext succeeded with a saturated limiter")
+    }
+}
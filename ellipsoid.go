@@ -0,0 +1,137 @@
+package geoutil
+
+import "math"
+
+// EarthModel is a reference surface used for distance calculations
+type EarthModel interface {
+    Distance(p1, p2 Point) float64 // Shortest distance in kilometers
+}
+
+var (
+    _ EarthModel = (*Ellipsoid)(nil)
+    _ EarthModel = Sphere{}
+)
+
+// Ellipsoid is a reference ellipsoid of revolution
+// Create one with NewEllipsoid; it is immutable and safe for concurrent use
+// The zero value and a nil pointer behave as WGS84
+type Ellipsoid struct {
+    name string  // Datum or ellipsoid name
+    a    float64 // Semi-major axis in kilometers
+    f    float64 // Flattening (0 for a sphere)
+    geod *geodesic
+}
+
+// Standard reference ellipsoids
+var (
+    WGS84      = NewEllipsoid("WGS84", 6378.137, 1/298.257223563)
+    GRS80      = NewEllipsoid("GRS80", 6378.137, 1/298.257222101)
+    Clarke1866 = NewEllipsoid("Clarke 1866", 6378.2064, 1/294.978698214)
+    Krassovsky = NewEllipsoid("Krassovsky 1940", 6378.245, 1/298.3)
+)
+
+// NewEllipsoid creates a custom reference ellipsoid
+// name: Descriptive name
+// a: Semi-major axis in kilometers
+// f: Flattening, e.g. 1/298.257223563
+func NewEllipsoid(name string, a, f float64) *Ellipsoid {
+    return &Ellipsoid{
+        name: name,
+        a:    a,
+        f:    f,
+        geod: newGeodesic(a, f),
+    }
+}
+
+// orDefault returns e, or WGS84 for a zero or nil ellipsoid that has no precomputed geodesic
+func (e *Ellipsoid) orDefault() *Ellipsoid {
+    if e == nil || e.geod == nil {
+        return WGS84
+    }
+    return e
+}
+
+// Name returns the datum or ellipsoid name
+func (e *Ellipsoid) Name() string {
+    return e.orDefault().name
+}
+
+// A returns the semi-major axis in kilometers
+func (e *Ellipsoid) A() float64 {
+    return e.orDefault().a
+}
+
+// F returns the flattening
+func (e *Ellipsoid) F() float64 {
+    return e.orDefault().f
+}
+
+// B returns the semi-minor axis in kilometers
+func (e *Ellipsoid) B() float64 {
+    e = e.orDefault()
+    return e.a * (1 - e.f)
+}
+
+// Distance calculates the geodesic distance using Karney's algorithm
+// p1, p2: Geographic points
+// Returns: Distance in kilometers
+func (e *Ellipsoid) Distance(p1, p2 Point) float64 {
+    return e.orDefault().geod.inverse(p1, p2).Distance
+}
+
+// Inverse solves the inverse geodesic problem using Karney's algorithm
+// p1, p2: Geographic points
+// Returns: Distance with initial and final azimuths
+func (e *Ellipsoid) Inverse(p1, p2 Point) InverseResult {
+    return e.orDefault().geod.inverse(p1, p2)
+}
+
+// Direct solves the direct geodesic problem using Karney's algorithm
+// p: Start point
+// bearing: Initial azimuth in degrees clockwise from north
+// distance: Distance in kilometers
+// Returns: Destination point and final azimuth in degrees [0, 360)
+func (e *Ellipsoid) Direct(p Point, bearing, distance float64) (Point, float64) {
+    return e.orDefault().geod.direct(p, bearing, distance)
+}
+
+// InverseVincenty solves the inverse geodesic problem using Vincenty's formula
+// p1, p2: Geographic points
+// Returns: Distance with initial and final azimuths, ErrInvalidCoordinate or ErrNoConvergence
+func (e *Ellipsoid) InverseVincenty(p1, p2 Point) (InverseResult, error) {
+    if err := p1.Validate(); err != nil {
+        return InverseResult{}, err
+    }
+    if err := p2.Validate(); err != nil {
+        return InverseResult{}, err
+    }
+    e = e.orDefault()
+    return vincentyInverse(e.a, e.f, p1, p2)
+}
+
+// Sphere is a spherical earth model with configurable radius
+type Sphere struct {
+    Radius float64 // Radius in kilometers
+}
+
+// EarthSphere is the mean-radius sphere used by DistanceHaversine
+var EarthSphere = Sphere{Radius: 6371}
+
+// Distance calculates great-circle distance using Haversine formula
+// p1, p2: Geographic points
+// Returns: Distance in kilometers
+func (s Sphere) Distance(p1, p2 Point) float64 {
+    φ1 := p1.Lat * math.Pi / 180
+    φ2 := p2.Lat * math.Pi / 180
+    Δφ := (p2.Lat - p1.Lat) * math.Pi / 180
+    Δλ := (p2.Lon - p1.Lon) * math.Pi / 180
+
+    a := math.Sin(Δφ/2)*math.Sin(Δφ/2) +
+        math.Cos(φ1)*math.Cos(φ2)*
+            math.Sin(Δλ/2)*math.Sin(Δλ/2)
+    // Rounding can push a slightly above 1 for antipodal points
+    a = math.Min(a, 1)
+    c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+    return s.Radius * c
+}
\ No newline at end of file
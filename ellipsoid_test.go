@@ -0,0 +1,144 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// dms converts degrees, minutes and seconds to decimal degrees
+func dms(d, m, s float64) float64 {
+    return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+}
+
+// Flinders Peak to Buninyong on GRS80, the worked example of Vincenty's inverse formula
+var (
+    flindersPeak = Point{Lat: dms(-37, 57, 3.72030), Lon: dms(144, 25, 29.52440)}
+    buninyong    = Point{Lat: dms(-37, 39, 10.15610), Lon: dms(143, 55, 35.38390)}
+)
+
+func TestEllipsoidInverseReference(t *testing.T) {
+    tests := []struct {
+        name           string
+        e              *Ellipsoid
+        p1, p2         Point
+        distance       float64 // Kilometers
+        initial, final float64 // Degrees
+        distTol        float64 // Kilometers
+        azTol          float64 // Degrees
+    }{
+        // GeographicLib's documented JFK to LHR example
+        {"JFK-LHR WGS84", WGS84, Point{40.6, -73.8}, Point{51.6, -0.5},
+            5551.759400319, 51.198882845580, 107.821776735514, 1e-9, 1e-11},
+        {"Flinders Peak-Buninyong GRS80", GRS80, flindersPeak, buninyong,
+            54.972271, dms(306, 52, 5.37), dms(127, 10, 25.07) + 180, 1e-6, 0.01 / 3600},
+        // Quarter and half meridians of WGS84
+        {"equator to pole", WGS84, Point{0, 0}, Point{90, 0}, 10001.965729230, 0, 0, 1e-6, 1e-9},
+        {"pole to pole", WGS84, Point{90, 0}, Point{-90, 0}, 20003.931458460, 180, 180, 1e-6, 1e-9},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            inv := tt.e.Inverse(tt.p1, tt.p2)
+            if math.Abs(inv.Distance-tt.distance) > tt.distTol {
+                t.Errorf("distance = %.9f km, want %.9f", inv.Distance, tt.distance)
+            }
+            if d := tt.e.Distance(tt.p1, tt.p2); d != inv.Distance {
+                t.Errorf("Distance = %v, Inverse.Distance = %v", d, inv.Distance)
+            }
+            if math.Abs(inv.InitialBearing-tt.initial) > tt.azTol {
+                t.Errorf("initial bearing = %.12f, want %.12f", inv.InitialBearing, tt.initial)
+            }
+            if math.Abs(inv.FinalBearing-tt.final) > tt.azTol {
+                t.Errorf("final bearing = %.12f, want %.12f", inv.FinalBearing, tt.final)
+            }
+        })
+    }
+}
+
+func TestEllipsoidParameters(t *testing.T) {
+    tests := []struct {
+        e    *Ellipsoid
+        name string
+        b    float64 // Published semi-minor axis in kilometers
+    }{
+        {WGS84, "WGS84", 6356.752314245},
+        {GRS80, "GRS80", 6356.752314140},
+        {Clarke1866, "Clarke 1866", 6356.583800},
+        {Krassovsky, "Krassovsky 1940", 6356.863019},
+    }
+    for _, tt := range tests {
+        if tt.e.Name() != tt.name {
+            t.Errorf("Name() = %q, want %q", tt.e.Name(), tt.name)
+        }
+        if math.Abs(tt.e.B()-tt.b) > 1e-6 {
+            t.Errorf("%s: B() = %.9f, want %.9f", tt.name, tt.e.B(), tt.b)
+        }
+    }
+}
+
+func TestEllipsoidsAgreeWithVincenty(t *testing.T) {
+    // Karney and Vincenty are independent algorithms that agree to well under a millimeter
+    pairs := [][2]Point{
+        {{40.6, -73.8}, {51.6, -0.5}},
+        {flindersPeak, buninyong},
+        {{-33.9, 151.2}, {35.7, 139.7}},
+        {{10, 170}, {-20, -160}},
+    }
+    for _, e := range []*Ellipsoid{WGS84, GRS80, Clarke1866, Krassovsky} {
+        for _, pair := range pairs {
+            v, err := e.InverseVincenty(pair[0], pair[1])
+            if err != nil {
+                t.Fatalf("%s: %v", e.Name(), err)
+            }
+            k := e.Inverse(pair[0], pair[1])
+            if math.Abs(v.Distance-k.Distance) > 1e-7 || math.Abs(v.InitialBearing-k.InitialBearing) > 1e-8 {
+                t.Errorf("%s %v: Vincenty %+v, Karney %+v", e.Name(), pair, v, k)
+            }
+        }
+    }
+}
+
+func TestEllipsoidDirectRoundTrip(t *testing.T) {
+    inv := GRS80.Inverse(flindersPeak, buninyong)
+    p, final := GRS80.Direct(flindersPeak, inv.InitialBearing, inv.Distance)
+    if !p.Equal(buninyong, 1e-6) || math.Abs(final-inv.FinalBearing) > 1e-9 {
+        t.Errorf("Direct = %v, %v; want %v, %v", p, final, buninyong, inv.FinalBearing)
+    }
+}
+
+func TestEllipsoidZeroValue(t *testing.T) {
+    var zero Ellipsoid
+    var null *Ellipsoid
+    p1, p2 := Point{40.6, -73.8}, Point{51.6, -0.5}
+    want := WGS84.Distance(p1, p2)
+    if d := zero.Distance(p1, p2); d != want {
+        t.Errorf("zero value Distance = %v, want WGS84 %v", d, want)
+    }
+    if d := null.Distance(p1, p2); d != want {
+        t.Errorf("nil Distance = %v, want WGS84 %v", d, want)
+    }
+    if zero.A() != WGS84.A() || zero.F() != WGS84.F() {
+        t.Errorf("zero value parameters = %v, %v; want WGS84", zero.A(), zero.F())
+    }
+}
+
+func TestSphereDistance(t *testing.T) {
+    tests := []struct {
+        name   string
+        s      Sphere
+        p1, p2 Point
+        want   float64
+        tol    float64
+    }{
+        // Veness's haversine example, Land's End to John o' Groats
+        {"Land's End-John o' Groats", EarthSphere,
+            Point{dms(50, 3, 59), dms(-5, 42, 53)}, Point{dms(58, 38, 38), dms(-3, 4, 12)}, 968.9, 0.05},
+        {"quarter meridian", EarthSphere, Point{0, 0}, Point{90, 0}, 6371 * math.Pi / 2, 1e-9},
+        {"antipodes", Sphere{Radius: 1737.4}, Point{0, 0}, Point{0, 180}, 1737.4 * math.Pi, 1e-9},
+        {"coincident", EarthSphere, Point{12, 34}, Point{12, 34}, 0, 0},
+    }
+    for _, tt := range tests {
+        if d := tt.s.Distance(tt.p1, tt.p2); math.Abs(d-tt.want) > tt.tol {
+            t.Errorf("%s: Distance = %.6f km, want %.6f", tt.name, d, tt.want)
+        }
+    }
+}
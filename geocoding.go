@@ -22,6 +22,8 @@ type NominatimGeocoder struct {
     config     GeocoderConfig
 }
 
+var _ GeocoderContext = (*NominatimGeocoder)(nil)
+
 // NewNominatimGeocoder creates a new Nominatim geocoder
 // config: Configuration parameters
 func NewNominatimGeocoder(config GeocoderConfig) *NominatimGeocoder {
@@ -48,13 +50,21 @@ func NewNominatimGeocoder(config GeocoderConfig) *NominatimGeocoder {
 // address: Human-readable address string
 // Returns: Geographic point or error
 func (n *NominatimGeocoder) Geocode(address string) (Point, error) {
+    return n.GeocodeContext(context.Background(), address)
+}
+
+// GeocodeContext converts address to geographic coordinates
+// ctx: Context controlling rate-limiter wait and HTTP request
+// address: Human-readable address string
+// Returns: Geographic point or error
+func (n *NominatimGeocoder) GeocodeContext(ctx context.Context, address string) (Point, error) {
     // Check cache first
     if val, found := n.cache.Get(address); found {
         return val.(Point), nil
     }
 
     // Apply rate limiting
-    ctx, cancel := context.WithTimeout(context.Background(), n.config.Timeout)
+    ctx, cancel := context.WithTimeout(ctx, n.config.Timeout)
     defer cancel()
     if err := n.limiter.Wait(ctx); err != nil {
         return Point{}, err
@@ -68,7 +78,7 @@ func (n *NominatimGeocoder) Geocode(address string) (Point, error) {
     }
     url := fmt.Sprintf("%s/search?%s", n.baseURL, params.Encode())
 
-    req, err := http.NewRequest("GET", url, nil)
+    req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
     if err != nil {
         return Point{}, err
     }
@@ -117,58 +127,40 @@ func (n *NominatimGeocoder) Geocode(address string) (Point, error) {
 
 // BatchGeocode processes multiple addresses concurrently
 // addresses: Slice of address strings
-// Returns: Slice of points or first error encountered
+// Returns: Slice of points (zero for failed items) and *BatchError if any item failed
 func (n *NominatimGeocoder) BatchGeocode(addresses []string) ([]Point, error) {
-    type result struct {
-        index int
-        point Point
-        err   error
-    }
-
-    results := make(chan result, len(addresses))
-    var wg sync.WaitGroup
-    sem := make(chan struct{}, 10) // Concurrency limiter
-
-    for i, addr := range addresses {
-        wg.Add(1)
-        go func(idx int, address string) {
-            defer wg.Done()
-            sem <- struct{}{}
-            defer func() { <-sem }()
-
-            point, err := n.Geocode(address)
-            results <- result{idx, point, err}
-        }(i, addr)
-    }
-
-    // Close results channel when all workers complete
-    go func() {
-        wg.Wait()
-        close(results)
-    }()
-
-    // Collect results
-    points := make([]Point, len(addresses))
-    for res := range results {
-        if res.err != nil {
-            return nil, res.err
-        }
-        points[res.index] = res.point
-    }
+    return n.BatchGeocodeContext(context.Background(), addresses)
+}
 
-    return points, nil
+// BatchGeocodeContext processes multiple addresses concurrently
+// ctx: Context controlling all requests; items not started before cancellation fail
+// addresses: Slice of address strings
+// Returns: Slice of points (zero for failed items) and *BatchError if any item failed
+func (n *NominatimGeocoder) BatchGeocodeContext(ctx context.Context, addresses []string) ([]Point, error) {
+    return runBatch(ctx, addresses, 10, n.GeocodeContext)
 }
 
 // ReverseGeocode converts coordinates to address information
 // p: Geographic point
 // Returns: Location details or error
 func (n *NominatimGeocoder) ReverseGeocode(p Point) (Location, error) {
+    return n.ReverseGeocodeContext(context.Background(), p)
+}
+
+// ReverseGeocodeContext converts coordinates to address information
+// ctx: Context controlling rate-limiter wait and HTTP request
+// p: Geographic point
+// Returns: Location details, or ErrInvalidCoordinate without a request for invalid points
+func (n *NominatimGeocoder) ReverseGeocodeContext(ctx context.Context, p Point) (Location, error) {
+    if err := p.Validate(); err != nil {
+        return Location{}, err
+    }
     cacheKey := fmt.Sprintf("reverse_%f_%f", p.Lat, p.Lon)
     if val, found := n.cache.Get(cacheKey); found {
         return val.(Location), nil
     }
 
-    ctx, cancel := context.WithTimeout(context.Background(), n.config.Timeout)
+    ctx, cancel := context.WithTimeout(ctx, n.config.Timeout)
     defer cancel()
     if err := n.limiter.Wait(ctx); err != nil {
         return Location{}, err
@@ -182,7 +174,7 @@ func (n *NominatimGeocoder) ReverseGeocode(p Point) (Location, error) {
     }
     url := fmt.Sprintf("%s/reverse?%s", n.baseURL, params.Encode())
 
-    req, err := http.NewRequest("GET", url, nil)
+    req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
     if err != nil {
         return Location{}, err
     }
@@ -222,4 +214,19 @@ func (n *NominatimGeocoder) ReverseGeocode(p Point) (Location, error) {
 
     n.cache.Set(cacheKey, loc)
     return loc, nil
+}
+
+// BatchReverseGeocode converts multiple points to address information concurrently
+// points: Slice of geographic points
+// Returns: Slice of locations (zero for failed items) and *BatchError if any item failed
+func (n *NominatimGeocoder) BatchReverseGeocode(points []Point) ([]Location, error) {
+    return n.BatchReverseGeocodeContext(context.Background(), points)
+}
+
+// BatchReverseGeocodeContext converts multiple points to address information concurrently
+// ctx: Context controlling all requests; items not started before cancellation fail
+// points: Slice of geographic points
+// Returns: Slice of locations (zero for failed items) and *BatchError if any item failed
+func (n *NominatimGeocoder) BatchReverseGeocodeContext(ctx context.Context, points []Point) ([]Location, error) {
+    return runBatch(ctx, points, 10, n.ReverseGeocodeContext)
 }
\ No newline at end of file
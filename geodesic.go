@@ -0,0 +1,997 @@
+package geoutil
+
+import "math"
+
+// InverseResult describes the solution of the inverse geodesic problem
+type InverseResult struct {
+    Distance       float64 // Geodesic distance in kilometers
+    InitialBearing float64 // Azimuth at the first point in degrees [0, 360)
+    FinalBearing   float64 // Azimuth at the second point in degrees [0, 360)
+}
+
+// DistanceGeodesic calculates the shortest distance on the WGS84 ellipsoid
+// Uses Karney's algorithm, accurate to nanometers and robust for near-antipodal points
+// p1, p2: Geographic points
+// Returns: Distance in kilometers
+func DistanceGeodesic(p1, p2 Point) float64 {
+    return WGS84.Distance(p1, p2)
+}
+
+// InverseGeodesic solves the inverse geodesic problem on the WGS84 ellipsoid
+// p1, p2: Geographic points
+// Returns: Distance with initial and final azimuths
+func InverseGeodesic(p1, p2 Point) InverseResult {
+    return WGS84.Inverse(p1, p2)
+}
+
+// Series orders and iteration limits of Karney's algorithm
+const (
+    geodOrder = 6
+    nA3       = geodOrder
+    nC3       = geodOrder
+    nC3x      = (nC3 * (nC3 - 1)) / 2
+    nC4       = geodOrder
+    nC4x      = (nC4 * (nC4 + 1)) / 2
+    maxit1    = 20
+    maxit2    = maxit1 + 53 + 10
+)
+
+var (
+    geodTiny    = math.Sqrt(0x1p-1022)
+    geodTol0    = 0x1p-52
+    geodTol1    = 200 * geodTol0
+    geodTol2    = math.Sqrt(geodTol0)
+    geodTolb    = geodTol0 * geodTol2
+    geodXthresh = 1000 * geodTol2
+)
+
+// geodesic holds precomputed ellipsoid constants for Karney's algorithm
+// (C. F. F. Karney, Algorithms for geodesics, J. Geodesy 87, 43-55, 2013)
+type geodesic struct {
+    a, f, f1, e2, ep2, n, b, c2, etol2 float64
+    a3x [nA3]float64
+    c3x [nC3x]float64
+    c4x [nC4x]float64
+}
+
+// newGeodesic precomputes series coefficients for an ellipsoid
+// a: Semi-major axis
+// f: Flattening
+func newGeodesic(a, f float64) *geodesic {
+    g := &geodesic{a: a, f: f}
+    g.f1 = 1 - f
+    g.e2 = f * (2 - f)
+    g.ep2 = g.e2 / sq(g.f1)
+    g.n = f / (2 - f)
+    g.b = a * g.f1
+
+    // Authalic radius squared, used for areas
+    switch {
+    case g.e2 == 0:
+        g.c2 = (sq(a) + sq(g.b)) / 2
+    case g.e2 > 0:
+        g.c2 = (sq(a) + sq(g.b)*math.Atanh(math.Sqrt(g.e2))/math.Sqrt(g.e2)) / 2
+    default:
+        g.c2 = (sq(a) + sq(g.b)*math.Atan(math.Sqrt(-g.e2))/math.Sqrt(-g.e2)) / 2
+    }
+    g.etol2 = 0.1 * geodTol2 /
+        math.Sqrt(math.Max(0.001, math.Abs(f))*math.Min(1, 1-f/2)/2)
+
+    g.a3coeff()
+    g.c3coeff()
+    g.c4coeff()
+    return g
+}
+
+// inverse computes distance and azimuths between two points
+func (g *geodesic) inverse(p1, p2 Point) InverseResult {
+    s12, salp1, calp1, salp2, calp2, _ := g.genInverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, false)
+    return InverseResult{
+        Distance:       s12,
+        InitialBearing: bearing360(atan2d(salp1, calp1)),
+        FinalBearing:   bearing360(atan2d(salp2, calp2)),
+    }
+}
+
+// direct computes the end point and final azimuth of a geodesic
+// p: Start point
+// azi1: Initial azimuth in degrees
+// s12: Distance along the geodesic
+func (g *geodesic) direct(p Point, azi1, s12 float64) (Point, float64) {
+    lat1 := latFix(p.Lat)
+    salp1, calp1 := sincosd(angRound(azi1))
+
+    // Parameters of the great ellipse on the auxiliary sphere
+    sbet1, cbet1 := sincosd(angRound(lat1))
+    sbet1 *= g.f1
+    sbet1, cbet1 = norm2(sbet1, cbet1)
+    cbet1 = math.Max(geodTiny, cbet1)
+
+    salp0 := salp1 * cbet1
+    calp0 := math.Hypot(calp1, salp1*sbet1)
+    ssig1 := sbet1
+    somg1 := salp0 * sbet1
+    csig1 := 1.0
+    if sbet1 != 0 || calp1 != 0 {
+        csig1 = cbet1 * calp1
+    }
+    comg1 := csig1
+    ssig1, csig1 = norm2(ssig1, csig1)
+
+    k2 := sq(calp0) * g.ep2
+    eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+
+    a1m1 := a1m1f(eps)
+    var c1a [nC1 + 1]float64
+    c1f(eps, c1a[:])
+    b11 := sinCosSeries(true, ssig1, csig1, c1a[:])
+    s, c := math.Sincos(b11)
+    stau1 := ssig1*c + csig1*s
+    ctau1 := csig1*c - ssig1*s
+
+    var c1pa [nC1p + 1]float64
+    c1pf(eps, c1pa[:])
+
+    a3c := -g.f * salp0 * g.a3f(eps)
+    var c3a [nC3]float64
+    g.c3f(eps, c3a[:])
+    b31 := sinCosSeries(true, ssig1, csig1, c3a[:])
+
+    // Convert distance to arc length on the auxiliary sphere
+    tau12 := s12 / (g.b * (1 + a1m1))
+    s, c = math.Sincos(tau12)
+    b12 := -sinCosSeries(true, stau1*c+ctau1*s, ctau1*c-stau1*s, c1pa[:])
+    sig12 := tau12 - (b12 - b11)
+    ssig12, csig12 := math.Sincos(sig12)
+    if math.Abs(g.f) > 0.01 {
+        // One Newton step for strongly flattened ellipsoids
+        ssig2 := ssig1*csig12 + csig1*ssig12
+        csig2 := csig1*csig12 - ssig1*ssig12
+        b12 = sinCosSeries(true, ssig2, csig2, c1a[:])
+        serr := (1+a1m1)*(sig12+(b12-b11)) - s12/g.b
+        sig12 -= serr / math.Sqrt(1+k2*sq(ssig2))
+        ssig12, csig12 = math.Sincos(sig12)
+    }
+
+    ssig2 := ssig1*csig12 + csig1*ssig12
+    csig2 := csig1*csig12 - ssig1*ssig12
+    sbet2 := calp0 * ssig2
+    cbet2 := math.Hypot(salp0, calp0*csig2)
+    if cbet2 == 0 {
+        // Geodesic ends at a pole
+        cbet2 = geodTiny
+        csig2 = geodTiny
+    }
+    salp2 := salp0
+    calp2 := calp0 * csig2
+
+    somg2 := salp0 * ssig2
+    comg2 := csig2
+    omg12 := math.Atan2(somg2*comg1-comg2*somg1, comg2*comg1+somg2*somg1)
+    lam12 := omg12 + a3c*(sig12+(sinCosSeries(true, ssig2, csig2, c3a[:])-b31))
+    lon2 := angNormalize(angNormalize(p.Lon) + angNormalize(lam12*radToDeg))
+    lat2 := atan2d(sbet2, g.f1*cbet2)
+
+    return Point{Lat: lat2, Lon: lon2}, bearing360(atan2d(salp2, calp2))
+}
+
+// genInverse solves the inverse problem
+// area: Also compute the area between the geodesic and the equator
+// Returns: Distance, sine/cosine of the azimuths at both points and the area S12
+func (g *geodesic) genInverse(lat1, lon1, lat2, lon2 float64, area bool) (s12, salp1, calp1, salp2, calp2, S12 float64) {
+    // Compute longitude difference exactly and reduce to [0, 180]
+    lon12, lon12s := angDiff(lon1, lon2)
+    lonsign := math.Copysign(1, lon12)
+    lon12 = lonsign * angRound(lon12)
+    lon12s = angRound((180 - lon12) - lonsign*lon12s)
+    lam12 := lon12 * math.Pi / 180
+    var slam12, clam12 float64
+    if lon12 > 90 {
+        slam12, clam12 = sincosd(lon12s)
+        clam12 = -clam12
+    } else {
+        slam12, clam12 = sincosd(lon12)
+    }
+
+    // Swap points so that |lat1| >= |lat2| and make lat1 <= 0
+    lat1 = angRound(latFix(lat1))
+    lat2 = angRound(latFix(lat2))
+    swapp := 1.0
+    if math.Abs(lat1) < math.Abs(lat2) || math.IsNaN(lat2) {
+        swapp = -1
+        lonsign *= -1
+        lat1, lat2 = lat2, lat1
+    }
+    latsign := math.Copysign(1, -lat1)
+    lat1 *= latsign
+    lat2 *= latsign
+
+    // Reduced latitudes
+    sbet1, cbet1 := sincosd(lat1)
+    sbet1 *= g.f1
+    sbet1, cbet1 = norm2(sbet1, cbet1)
+    cbet1 = math.Max(geodTiny, cbet1)
+    sbet2, cbet2 := sincosd(lat2)
+    sbet2 *= g.f1
+    sbet2, cbet2 = norm2(sbet2, cbet2)
+    cbet2 = math.Max(geodTiny, cbet2)
+
+    // Make sure equal latitudes stay equal after rounding
+    if cbet1 < -sbet1 {
+        if cbet2 == cbet1 {
+            sbet2 = math.Copysign(sbet1, sbet2)
+        }
+    } else if math.Abs(sbet2) == -sbet1 {
+        cbet2 = cbet1
+    }
+
+    dn1 := math.Sqrt(1 + g.ep2*sq(sbet1))
+    dn2 := math.Sqrt(1 + g.ep2*sq(sbet2))
+
+    var c1a [nC1 + 1]float64
+    var c2a [nC2 + 1]float64
+    var c3a [nC3]float64
+
+    var sig12, s12x float64
+    // somg12 > 1 marks sin/cos of omg12 as not yet computed
+    omg12, somg12, comg12 := 0.0, 2.0, 0.0
+    meridian := lat1 == -90 || slam12 == 0
+    if meridian {
+        // Endpoints lie on a single meridian (or one point is a pole)
+        calp1, salp1 = clam12, slam12
+        calp2, salp2 = 1, 0
+        ssig1, csig1 := sbet1, calp1*cbet1
+        ssig2, csig2 := sbet2, calp2*cbet2
+        sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+        var m12x float64
+        s12x, m12x, _ = g.lengths(g.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a[:], c2a[:])
+        // A meridian is the shortest path unless it passes through a conjugate point
+        if sig12 < 1 || m12x >= 0 {
+            if sig12 < 3*geodTiny || (sig12 < geodTol0 && (s12x < 0 || m12x < 0)) {
+                s12x = 0
+            }
+            s12x *= g.b
+        } else {
+            meridian = false
+        }
+    }
+
+    if !meridian && sbet1 == 0 && (g.f <= 0 || lon12s >= g.f*180) {
+        // Geodesic runs along the equator
+        calp1, calp2 = 0, 0
+        salp1, salp2 = 1, 1
+        s12x = g.a * lam12
+        omg12 = lam12 / g.f1
+    } else if !meridian {
+        var dnm float64
+        sig12, salp1, calp1, salp2, calp2, dnm = g.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2,
+            lam12, slam12, clam12, c1a[:], c2a[:])
+
+        if sig12 >= 0 {
+            // Short lines are solved directly by the starting guess
+            s12x = sig12 * g.b * dnm
+            omg12 = lam12 / (g.f1 * dnm)
+        } else {
+            // Newton's method on alp1, falling back to bisection
+            var ssig1, csig1, ssig2, csig2, eps, domg12 float64
+            tripn, tripb := false, false
+            salp1a, calp1a := geodTiny, 1.0
+            salp1b, calp1b := geodTiny, -1.0
+            for numit := 0; numit < maxit2; {
+                var v, dv float64
+                v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dv = g.lambda12(
+                    sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12,
+                    numit < maxit1, c1a[:], c2a[:], c3a[:])
+                tol := geodTol0
+                if tripn {
+                    tol *= 8
+                }
+                if tripb || !(math.Abs(v) >= tol) {
+                    break
+                }
+
+                // Update bracketing values
+                if v > 0 && (numit > maxit1 || calp1/salp1 > calp1b/salp1b) {
+                    salp1b, calp1b = salp1, calp1
+                } else if v < 0 && (numit > maxit1 || calp1/salp1 < calp1a/salp1a) {
+                    salp1a, calp1a = salp1, calp1
+                }
+
+                numit++
+                if numit < maxit1 && dv > 0 {
+                    dalp1 := -v / dv
+                    if math.Abs(dalp1) < math.Pi {
+                        sdalp1, cdalp1 := math.Sincos(dalp1)
+                        nsalp1 := salp1*cdalp1 + calp1*sdalp1
+                        if nsalp1 > 0 {
+                            calp1 = calp1*cdalp1 - salp1*sdalp1
+                            salp1 = nsalp1
+                            salp1, calp1 = norm2(salp1, calp1)
+                            tripn = math.Abs(v) <= 16*geodTol0
+                            continue
+                        }
+                    }
+                }
+
+                // Newton step left the bracket; bisect instead
+                salp1 = (salp1a + salp1b) / 2
+                calp1 = (calp1a + calp1b) / 2
+                salp1, calp1 = norm2(salp1, calp1)
+                tripn = false
+                tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < geodTolb ||
+                    math.Abs(salp1-salp1b)+(calp1-calp1b) < geodTolb
+            }
+            s12x, _, _ = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a[:], c2a[:])
+            s12x *= g.b
+
+            // omg12 = lam12 - domg12
+            sdomg12, cdomg12 := math.Sincos(domg12)
+            somg12 = slam12*cdomg12 - clam12*sdomg12
+            comg12 = clam12*cdomg12 + slam12*sdomg12
+        }
+    }
+
+    s12 = 0 + s12x
+
+    if area {
+        S12 = g.areaTerm(sbet1, cbet1, sbet2, cbet2, salp1, calp1, salp2, calp2, meridian, omg12, somg12, comg12)
+        S12 *= swapp * lonsign * latsign
+        // Convert -0 to 0
+        S12 += 0
+    }
+
+    // Undo the swap and sign changes
+    if swapp < 0 {
+        salp1, salp2 = salp2, salp1
+        calp1, calp2 = calp2, calp1
+    }
+    salp1 *= swapp * lonsign
+    calp1 *= swapp * latsign
+    salp2 *= swapp * lonsign
+    calp2 *= swapp * latsign
+    return s12, salp1, calp1, salp2, calp2, S12
+}
+
+// areaTerm computes the area between a geodesic and the equator for genInverse
+// Arguments are the reduced latitudes and azimuths before the final sign changes
+func (g *geodesic) areaTerm(sbet1, cbet1, sbet2, cbet2, salp1, calp1, salp2, calp2 float64,
+    meridian bool, omg12, somg12, comg12 float64) float64 {
+    // From lambda12: sin(alp1) * cos(bet1) = sin(alp0)
+    salp0 := salp1 * cbet1
+    calp0 := math.Hypot(calp1, salp1*sbet1)
+    var S12 float64
+    if calp0 != 0 && salp0 != 0 {
+        // From lambda12: tan(bet) = tan(sig) * cos(alp)
+        ssig1, csig1 := norm2(sbet1, calp1*cbet1)
+        ssig2, csig2 := norm2(sbet2, calp2*cbet2)
+        k2 := sq(calp0) * g.ep2
+        eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+        // Multiplier = a^2 * e^2 * cos(alpha0) * sin(alpha0)
+        a4 := sq(g.a) * calp0 * salp0 * g.e2
+        var c4a [nC4]float64
+        g.c4f(eps, c4a[:])
+        b41 := sinCosSeries(false, ssig1, csig1, c4a[:])
+        b42 := sinCosSeries(false, ssig2, csig2, c4a[:])
+        S12 = a4 * (b42 - b41)
+    }
+    // Otherwise sig1 and sig2 are indeterminate on the equator and S12 stays 0
+
+    if !meridian && somg12 > 1 {
+        somg12, comg12 = math.Sincos(omg12)
+    }
+
+    var alp12 float64
+    if !meridian && comg12 > -0.7071 && sbet2-sbet1 < 1.75 {
+        // Short lines: tan(alp12/2) from omg12 and the half-angle tangents of bet1, bet2
+        domg12 := 1 + comg12
+        dbet1 := 1 + cbet1
+        dbet2 := 1 + cbet2
+        alp12 = 2 * math.Atan2(somg12*(sbet1*dbet2+sbet2*dbet1), domg12*(sbet1*sbet2+dbet1*dbet2))
+    } else {
+        // alp12 = alp2 - alp1, used in atan2 so no need to normalize
+        salp12 := salp2*calp1 - calp2*salp1
+        calp12 := calp2*calp1 + salp2*salp1
+        // Attach the correct sign to a zero salp12 when alp1 = ±180 and alp2 = 0
+        if salp12 == 0 && calp12 < 0 {
+            salp12 = geodTiny * calp1
+            calp12 = -1
+        }
+        alp12 = math.Atan2(salp12, calp12)
+    }
+    return S12 + g.c2*alp12
+}
+
+// lengths computes the distance and reduced length along a geodesic, scaled by b
+// Returns: Distance, reduced length and the m0 coefficient
+func (g *geodesic) lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64, c1a, c2a []float64) (s12b, m12b, m0 float64) {
+    a1 := a1m1f(eps)
+    c1f(eps, c1a)
+    a2 := a2m1f(eps)
+    c2f(eps, c2a)
+    m0 = a1 - a2
+    a2 = 1 + a2
+    a1 = 1 + a1
+
+    b1 := sinCosSeries(true, ssig2, csig2, c1a) - sinCosSeries(true, ssig1, csig1, c1a)
+    s12b = a1 * (sig12 + b1)
+    b2 := sinCosSeries(true, ssig2, csig2, c2a) - sinCosSeries(true, ssig1, csig1, c2a)
+    j12 := m0*sig12 + (a1*b1 - a2*b2)
+    m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+    return s12b, m12b, m0
+}
+
+// inverseStart returns a starting azimuth for Newton's method
+// A non-negative sig12 means the short-line solution is already final
+func (g *geodesic) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64, c1a, c2a []float64) (sig12, salp1, calp1, salp2, calp2, dnm float64) {
+    sig12 = -1
+    salp2, calp2, dnm = math.NaN(), math.NaN(), math.NaN()
+
+    sbet12 := sbet2*cbet1 - cbet2*sbet1
+    cbet12 := cbet2*cbet1 + sbet2*sbet1
+    sbet12a := sbet2*cbet1 + cbet2*sbet1
+
+    shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+    var somg12, comg12 float64
+    if shortline {
+        sbetm2 := sq(sbet1 + sbet2)
+        sbetm2 /= sbetm2 + sq(cbet1+cbet2)
+        dnm = math.Sqrt(1 + g.ep2*sbetm2)
+        omg12 := lam12 / (g.f1 * dnm)
+        somg12, comg12 = math.Sincos(omg12)
+    } else {
+        somg12, comg12 = slam12, clam12
+    }
+
+    salp1 = cbet2 * somg12
+    if comg12 >= 0 {
+        calp1 = sbet12 + cbet2*sbet1*sq(somg12)/(1+comg12)
+    } else {
+        calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+    }
+
+    ssig12 := math.Hypot(salp1, calp1)
+    csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+    if shortline && ssig12 < g.etol2 {
+        // Really short lines
+        salp2 = cbet1 * somg12
+        if comg12 >= 0 {
+            calp2 = sbet12 - cbet1*sbet2*(sq(somg12)/(1+comg12))
+        } else {
+            calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+        }
+        salp2, calp2 = norm2(salp2, calp2)
+        sig12 = math.Atan2(ssig12, csig12)
+    } else if math.Abs(g.n) >= 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(g.n)*math.Pi*sq(cbet1) {
+        // Nothing to do, the zeroth order spherical approximation is fine
+    } else {
+        // Nearly antipodal points: scale onto the astroid problem
+        var x, y, lamscale, betscale float64
+        lam12x := math.Atan2(-slam12, -clam12)
+        if g.f >= 0 {
+            k2 := sq(sbet1) * g.ep2
+            eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+            lamscale = g.f * cbet1 * g.a3f(eps) * math.Pi
+            betscale = lamscale * cbet1
+            x = lam12x / lamscale
+            y = sbet12a / betscale
+        } else {
+            cbet12a := cbet2*cbet1 - sbet2*sbet1
+            bet12a := math.Atan2(sbet12a, cbet12a)
+            _, m12b, m0 := g.lengths(g.n, math.Pi+bet12a, sbet1, -cbet1, dn1, sbet2, cbet2, dn2, c1a, c2a)
+            x = -1 + m12b/(cbet1*cbet2*m0*math.Pi)
+            if x < -0.01 {
+                betscale = sbet12a / x
+            } else {
+                betscale = -g.f * sq(cbet1) * math.Pi
+            }
+            lamscale = betscale / cbet1
+            y = lam12x / lamscale
+        }
+
+        if y > -geodTol1 && x > -1-geodXthresh {
+            if g.f >= 0 {
+                salp1 = math.Min(1, -x)
+                calp1 = -math.Sqrt(1 - sq(salp1))
+            } else {
+                if x > -geodTol1 {
+                    calp1 = math.Max(0, x)
+                } else {
+                    calp1 = math.Max(-1, x)
+                }
+                salp1 = math.Sqrt(1 - sq(calp1))
+            }
+        } else {
+            k := astroid(x, y)
+            var omg12a float64
+            if g.f >= 0 {
+                omg12a = lamscale * (-x * k / (1 + k))
+            } else {
+                omg12a = lamscale * (-y * (1 + k) / k)
+            }
+            somg12, comg12 = math.Sincos(omg12a)
+            comg12 = -comg12
+            salp1 = cbet2 * somg12
+            calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+        }
+    }
+
+    if !(salp1 <= 0) {
+        salp1, calp1 = norm2(salp1, calp1)
+    } else {
+        salp1, calp1 = 1, 0
+    }
+    return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// lambda12 evaluates the longitude error for a trial azimuth and its derivative
+func (g *geodesic) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64,
+    diffp bool, c1a, c2a, c3a []float64) (lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dlam12 float64) {
+    if sbet1 == 0 && calp1 == 0 {
+        // Break degeneracy of equatorial line
+        calp1 = -geodTiny
+    }
+
+    salp0 := salp1 * cbet1
+    calp0 := math.Hypot(calp1, salp1*sbet1)
+
+    ssig1 = sbet1
+    somg1 := salp0 * sbet1
+    csig1 = calp1 * cbet1
+    comg1 := csig1
+    ssig1, csig1 = norm2(ssig1, csig1)
+
+    if cbet2 != cbet1 {
+        salp2 = salp0 / cbet2
+    } else {
+        salp2 = salp1
+    }
+    if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+        var d float64
+        if cbet1 < -sbet1 {
+            d = (cbet2 - cbet1) * (cbet1 + cbet2)
+        } else {
+            d = (sbet1 - sbet2) * (sbet1 + sbet2)
+        }
+        calp2 = math.Sqrt(sq(calp1*cbet1)+d) / cbet2
+    } else {
+        calp2 = math.Abs(calp1)
+    }
+
+    ssig2 = sbet2
+    somg2 := salp0 * sbet2
+    csig2 = calp2 * cbet2
+    comg2 := csig2
+    ssig2, csig2 = norm2(ssig2, csig2)
+
+    sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+    somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+    comg12 := comg1*comg2 + somg1*somg2
+    eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+    k2 := sq(calp0) * g.ep2
+    eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+    g.c3f(eps, c3a)
+    b312 := sinCosSeries(true, ssig2, csig2, c3a) - sinCosSeries(true, ssig1, csig1, c3a)
+    domg12 = -g.f * g.a3f(eps) * salp0 * (sig12 + b312)
+    lam12 = eta + domg12
+
+    if diffp {
+        if calp2 == 0 {
+            dlam12 = -2 * g.f1 * dn1 / sbet1
+        } else {
+            _, dlam12, _ = g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, c1a, c2a)
+            dlam12 *= g.f1 / (calp2 * cbet2)
+        }
+    } else {
+        dlam12 = math.NaN()
+    }
+    return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dlam12
+}
+
+// astroid solves k^4+2k^3-(x^2+y^2-1)k^2-2y^2k-y^2 = 0 for the positive root
+func astroid(x, y float64) float64 {
+    p := sq(x)
+    q := sq(y)
+    r := (p + q - 1) / 6
+    if q == 0 && r <= 0 {
+        return 0
+    }
+    s := p * q / 4
+    r2 := sq(r)
+    r3 := r * r2
+    disc := s * (s + 2*r3)
+    u := r
+    if disc >= 0 {
+        t3 := s + r3
+        if t3 < 0 {
+            t3 -= math.Sqrt(disc)
+        } else {
+            t3 += math.Sqrt(disc)
+        }
+        t := math.Cbrt(t3)
+        u += t
+        if t != 0 {
+            u += r2 / t
+        }
+    } else {
+        ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+        u += 2 * r * math.Cos(ang/3)
+    }
+    v := math.Sqrt(sq(u) + q)
+    var uv float64
+    if u < 0 {
+        uv = q / (v - u)
+    } else {
+        uv = u + v
+    }
+    w := (uv - q) / (2 * v)
+    return uv / (math.Sqrt(uv+sq(w)) + w)
+}
+
+// a3f evaluates the A3 coefficient
+func (g *geodesic) a3f(eps float64) float64 {
+    return polyval(nA3-1, g.a3x[:], 0, eps)
+}
+
+// c3f evaluates the C3 coefficients, c[0] is unused
+func (g *geodesic) c3f(eps float64, c []float64) {
+    mult := 1.0
+    o := 0
+    for l := 1; l < nC3; l++ {
+        m := nC3 - l - 1
+        mult *= eps
+        c[l] = mult * polyval(m, g.c3x[:], o, eps)
+        o += m + 1
+    }
+}
+
+// a3coeff precomputes the polynomials in n for A3
+func (g *geodesic) a3coeff() {
+    coeff := [...]float64{
+        -3, 128,
+        -2, -3, 64,
+        -1, -3, -1, 16,
+        3, -1, -2, 8,
+        1, -1, 2,
+        1, 1,
+    }
+    o, k := 0, 0
+    for j := nA3 - 1; j >= 0; j-- {
+        m := min(nA3-j-1, j)
+        g.a3x[k] = polyval(m, coeff[:], o, g.n) / coeff[o+m+1]
+        k++
+        o += m + 2
+    }
+}
+
+// c3coeff precomputes the polynomials in n for C3
+func (g *geodesic) c3coeff() {
+    coeff := [...]float64{
+        3, 128,
+        2, 5, 128,
+        -1, 3, 3, 64,
+        -1, 0, 1, 8,
+        -1, 1, 4,
+        5, 256,
+        1, 3, 128,
+        -3, -2, 3, 64,
+        1, -3, 2, 32,
+        7, 512,
+        -10, 9, 384,
+        5, -9, 5, 192,
+        7, 512,
+        -14, 7, 512,
+        21, 2560,
+    }
+    o, k := 0, 0
+    for l := 1; l < nC3; l++ {
+        for j := nC3 - 1; j >= l; j-- {
+            m := min(nC3-j-1, j)
+            g.c3x[k] = polyval(m, coeff[:], o, g.n) / coeff[o+m+1]
+            k++
+            o += m + 2
+        }
+    }
+}
+
+// c4f evaluates the C4 coefficients used for areas
+func (g *geodesic) c4f(eps float64, c []float64) {
+    mult := 1.0
+    o := 0
+    for l := 0; l < nC4; l++ {
+        m := nC4 - l - 1
+        c[l] = mult * polyval(m, g.c4x[:], o, eps)
+        o += m + 1
+        mult *= eps
+    }
+}
+
+// c4coeff precomputes the polynomials in n for C4
+func (g *geodesic) c4coeff() {
+    coeff := [...]float64{
+        97, 15015,
+        1088, 156, 45045,
+        -224, -4784, 1573, 45045,
+        -10656, 14144, -4576, -858, 45045,
+        64, 624, -4576, 6864, -3003, 15015,
+        100, 208, 572, 3432, -12012, 30030, 45045,
+        1, 9009,
+        -2944, 468, 135135,
+        5792, 1040, -1287, 135135,
+        5952, -11648, 9152, -2574, 135135,
+        -64, -624, 4576, -6864, 3003, 135135,
+        8, 10725,
+        1856, -936, 225225,
+        -8448, 4992, -1144, 225225,
+        -1440, 4160, -4576, 1716, 225225,
+        -136, 63063,
+        1024, -208, 105105,
+        3584, -3328, 1144, 315315,
+        -128, 135135,
+        -2560, 832, 405405,
+        128, 99099,
+    }
+    o, k := 0, 0
+    for l := 0; l < nC4; l++ {
+        for j := nC4 - 1; j >= l; j-- {
+            m := nC4 - j - 1
+            g.c4x[k] = polyval(m, coeff[:], o, g.n) / coeff[o+m+1]
+            k++
+            o += m + 2
+        }
+    }
+}
+
+// Series orders for the distance and reduced length expansions
+const (
+    nA1  = geodOrder
+    nC1  = geodOrder
+    nC1p = geodOrder
+    nA2  = geodOrder
+    nC2  = geodOrder
+)
+
+// a1m1f evaluates A1 - 1
+func a1m1f(eps float64) float64 {
+    coeff := [...]float64{1, 4, 64, 0, 256}
+    m := nA1 / 2
+    t := polyval(m, coeff[:], 0, sq(eps)) / coeff[m+1]
+    return (t + eps) / (1 - eps)
+}
+
+// c1f evaluates the C1 coefficients, c[0] is unused
+func c1f(eps float64, c []float64) {
+    coeff := [...]float64{
+        -1, 6, -16, 32,
+        -9, 64, -128, 2048,
+        9, -16, 768,
+        3, -5, 512,
+        -7, 1280,
+        -7, 2048,
+    }
+    eps2 := sq(eps)
+    d := eps
+    o := 0
+    for l := 1; l <= nC1; l++ {
+        m := (nC1 - l) / 2
+        c[l] = d * polyval(m, coeff[:], o, eps2) / coeff[o+m+1]
+        o += m + 2
+        d *= eps
+    }
+}
+
+// c1pf evaluates the C1' coefficients of the reverted series, c[0] is unused
+func c1pf(eps float64, c []float64) {
+    coeff := [...]float64{
+        205, -432, 768, 1536,
+        4005, -4736, 3840, 12288,
+        -225, 116, 384,
+        -7173, 2695, 7680,
+        3467, 7680,
+        38081, 61440,
+    }
+    eps2 := sq(eps)
+    d := eps
+    o := 0
+    for l := 1; l <= nC1p; l++ {
+        m := (nC1p - l) / 2
+        c[l] = d * polyval(m, coeff[:], o, eps2) / coeff[o+m+1]
+        o += m + 2
+        d *= eps
+    }
+}
+
+// a2m1f evaluates A2 - 1
+func a2m1f(eps float64) float64 {
+    coeff := [...]float64{-11, -28, -192, 0, 256}
+    m := nA2 / 2
+    t := polyval(m, coeff[:], 0, sq(eps)) / coeff[m+1]
+    return (t - eps) / (1 + eps)
+}
+
+// c2f evaluates the C2 coefficients, c[0] is unused
+func c2f(eps float64, c []float64) {
+    coeff := [...]float64{
+        1, 2, 16, 32,
+        35, 64, 384, 2048,
+        15, 80, 768,
+        7, 35, 512,
+        63, 1280,
+        77, 2048,
+    }
+    eps2 := sq(eps)
+    d := eps
+    o := 0
+    for l := 1; l <= nC2; l++ {
+        m := (nC2 - l) / 2
+        c[l] = d * polyval(m, coeff[:], o, eps2) / coeff[o+m+1]
+        o += m + 2
+        d *= eps
+    }
+}
+
+// sinCosSeries evaluates a trigonometric series with Clenshaw summation
+// sinp: Sum sine terms (c[0] unused) instead of cosine terms
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64) float64 {
+    k := len(c)
+    n := k
+    if sinp {
+        n--
+    }
+    ar := 2 * (cosx - sinx) * (cosx + sinx)
+    var y0, y1 float64
+    if n&1 != 0 {
+        k--
+        y0 = c[k]
+    }
+    for n /= 2; n > 0; n-- {
+        k--
+        y1 = ar*y0 - y1 + c[k]
+        k--
+        y0 = ar*y1 - y0 + c[k]
+    }
+    if sinp {
+        return 2 * sinx * cosx * y0
+    }
+    return cosx * (y0 - y1)
+}
+
+// polyval evaluates a polynomial of degree n with coefficients p[s:s+n+1]
+func polyval(n int, p []float64, s int, x float64) float64 {
+    if n < 0 {
+        return 0
+    }
+    y := p[s]
+    for ; n > 0; n-- {
+        s++
+        y = y*x + p[s]
+    }
+    return y
+}
+
+func sq(x float64) float64 {
+    return x * x
+}
+
+// norm2 scales a sine/cosine pair to unit length
+func norm2(x, y float64) (float64, float64) {
+    r := math.Hypot(x, y)
+    return x / r, y / r
+}
+
+// angRound coarsens tiny angles so that they are represented exactly
+func angRound(x float64) float64 {
+    const z = 1.0 / 16
+    y := math.Abs(x)
+    if y < z {
+        y = z - (z - y)
+    }
+    if x == 0 {
+        return 0
+    }
+    return math.Copysign(y, x)
+}
+
+// angNormalize reduces an angle in degrees to [-180, 180]
+func angNormalize(x float64) float64 {
+    y := math.Remainder(x, 360)
+    if math.Abs(y) == 180 {
+        return math.Copysign(180, x)
+    }
+    return y
+}
+
+// angDiff computes y - x in degrees reduced to [-180, 180] with its rounding error
+func angDiff(x, y float64) (float64, float64) {
+    d, t := twoSum(math.Remainder(-x, 360), math.Remainder(y, 360))
+    d, t = twoSum(math.Remainder(d, 360), t)
+    if d == 0 || math.Abs(d) == 180 {
+        if t == 0 {
+            d = math.Copysign(d, y-x)
+        } else {
+            d = math.Copysign(d, -t)
+        }
+    }
+    return d, t
+}
+
+// twoSum returns the rounded sum and its exact error
+func twoSum(u, v float64) (float64, float64) {
+    s := u + v
+    up := s - v
+    vpp := s - up
+    up -= u
+    vpp -= v
+    if s == 0 {
+        return s, s
+    }
+    return s, 0 - (up + vpp)
+}
+
+// latFix replaces out-of-range latitudes with NaN
+func latFix(x float64) float64 {
+    if math.Abs(x) > 90 {
+        return math.NaN()
+    }
+    return x
+}
+
+// sincosd computes sine and cosine of an angle in degrees with exact quadrants
+func sincosd(x float64) (float64, float64) {
+    r := math.NaN()
+    if !math.IsInf(x, 0) {
+        r = math.Mod(x, 360)
+    }
+    q := 0
+    if !math.IsNaN(r) {
+        q = int(math.RoundToEven(r / 90))
+    }
+    r -= 90 * float64(q)
+    s, c := math.Sincos(r * math.Pi / 180)
+    switch ((q % 4) + 4) % 4 {
+    case 1:
+        s, c = c, -s
+    case 2:
+        s, c = -s, -c
+    case 3:
+        s, c = -c, s
+    }
+    c += 0
+    if s == 0 {
+        s = math.Copysign(0, x)
+    }
+    return s, c
+}
+
+// atan2d computes atan2 in degrees with exact results for quadrant boundaries
+func atan2d(y, x float64) float64 {
+    q := 0
+    if math.Abs(y) > math.Abs(x) {
+        q = 2
+        x, y = y, x
+    }
+    if math.Signbit(x) {
+        q++
+        x = -x
+    }
+    ang := math.Atan2(y, x) * 180 / math.Pi
+    switch q {
+    case 1:
+        ang = math.Copysign(180, y) - ang
+    case 2:
+        ang = 90 - ang
+    case 3:
+        ang = -90 + ang
+    }
+    return ang
+}
+
+// bearing360 maps an azimuth in degrees to [0, 360)
+func bearing360(azi float64) float64 {
+    b := math.Mod(azi, 360)
+    if b < 0 {
+        b += 360
+    }
+    return b + 0
+}
\ No newline at end of file
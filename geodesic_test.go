@@ -0,0 +1,120 @@
+package geoutil
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+func TestInverseWGS84(t *testing.T) {
+    tests := []struct {
+        name           string
+        p1, p2         Point
+        distance       float64 // Kilometers
+        initial, final float64 // Degrees, NaN to skip
+        vincenty       error   // Expected InverseVincenty error
+    }{
+        {"JFK-LHR", Point{40.6, -73.8}, Point{51.6, -0.5}, 5551.759400319, 51.198882845580, 107.821776735514, nil},
+        {"coincident", Point{12.5, 45}, Point{12.5, 45}, 0, math.NaN(), math.NaN(), nil},
+        {"equator to pole", Point{0, 0}, Point{90, 0}, 10001.965729230, 0, 0, nil},
+        {"both at north pole", Point{90, 0}, Point{90, 90}, 0, math.NaN(), math.NaN(), nil},
+        // Longitudes ±180 name the same meridian
+        {"antimeridian", Point{10, 180}, Point{10, -180}, 0, math.NaN(), math.NaN(), nil},
+        // Vincenty's iteration fails for these antipodal and nearly antipodal points
+        {"equatorial antipodes", Point{0, 0}, Point{0, 180}, 20003.931458460, math.NaN(), math.NaN(), ErrNoConvergence},
+        {"nearly antipodal", Point{0, 0}, Point{0.5, 179.7}, 19944.127420750, 15.556882793, 164.442513891, ErrNoConvergence},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            inv := InverseGeodesic(tt.p1, tt.p2)
+            if math.Abs(inv.Distance-tt.distance) > 1e-6 {
+                t.Errorf("InverseGeodesic distance = %.9f, want %.9f", inv.Distance, tt.distance)
+            }
+            if !math.IsNaN(tt.initial) && math.Abs(inv.InitialBearing-tt.initial) > 1e-8 {
+                t.Errorf("initial bearing = %.12f, want %.12f", inv.InitialBearing, tt.initial)
+            }
+            if !math.IsNaN(tt.final) && math.Abs(inv.FinalBearing-tt.final) > 1e-8 {
+                t.Errorf("final bearing = %.12f, want %.12f", inv.FinalBearing, tt.final)
+            }
+            if d := DistanceGeodesic(tt.p1, tt.p2); d != inv.Distance {
+                t.Errorf("DistanceGeodesic = %v, want %v", d, inv.Distance)
+            }
+
+            v, err := InverseVincenty(tt.p1, tt.p2)
+            if !errors.Is(err, tt.vincenty) {
+                t.Fatalf("InverseVincenty error = %v, want %v", err, tt.vincenty)
+            }
+            if err == nil && math.Abs(v.Distance-tt.distance) > 1e-6 {
+                t.Errorf("InverseVincenty distance = %.9f, want %.9f", v.Distance, tt.distance)
+            }
+            // DistanceVincenty falls back to Karney where Vincenty fails
+            if d := DistanceVincenty(tt.p1, tt.p2); math.Abs(d-tt.distance) > 1e-6 {
+                t.Errorf("DistanceVincenty = %.9f, want %.9f", d, tt.distance)
+            }
+        })
+    }
+}
+
+func TestInverseVincentyInvalid(t *testing.T) {
+    for _, p := range []Point{{math.NaN(), 0}, {91, 0}, {0, math.Inf(1)}} {
+        if _, err := InverseVincenty(Point{0, 0}, p); !errors.Is(err, ErrInvalidCoordinate) {
+            t.Errorf("InverseVincenty(%v) error = %v, want ErrInvalidCoordinate", p, err)
+        }
+    }
+}
+
+func TestDestinationGeodesic(t *testing.T) {
+    tests := []struct {
+        name     string
+        e        *Ellipsoid
+        p        Point
+        bearing  float64
+        distance float64
+        want     Point
+        final    float64
+        tol      float64 // Meters
+    }{
+        // Vincenty's direct example on GRS80
+        {"Flinders Peak", GRS80, flindersPeak, dms(306, 52, 5.37), 54.972271, buninyong, dms(127, 10, 25.07) + 180, 1e-3},
+        {"quarter meridian", WGS84, Point{0, 0}, 0, 10001.965729230, Point{90, 0}, 0, 1e-3},
+        {"along the equator", WGS84, Point{0, 170}, 90, 6378.137 * math.Pi / 9, Point{0, -170}, 90, 1e-3},
+        {"zero distance", WGS84, Point{-33.9, 151.2}, 123, 0, Point{-33.9, 151.2}, 123, 1e-9},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            p, final := tt.e.Direct(tt.p, tt.bearing, tt.distance)
+            if tt.e == WGS84 {
+                q, f := DestinationGeodesic(tt.p, tt.bearing, tt.distance)
+                if q != p || f != final {
+                    t.Errorf("DestinationGeodesic = %v, %v; want %v, %v", q, f, p, final)
+                }
+            }
+            if !p.Equal(tt.want, tt.tol) {
+                t.Errorf("destination = %v, want %v", p, tt.want)
+            }
+            if math.Abs(final-tt.final) > 1e-6 {
+                t.Errorf("final bearing = %.9f, want %.9f", final, tt.final)
+            }
+        })
+    }
+}
+
+func TestDestinationGeodesicMatchesVincenty(t *testing.T) {
+    // Direct solutions must agree with the independent Vincenty inverse within its
+    // accuracy of about half a millimeter
+    start := Point{40.6, -73.8}
+    for _, bearing := range []float64{0, 45, 135, 200, 315} {
+        for _, distance := range []float64{1, 500, 10000} {
+            p, final := DestinationGeodesic(start, bearing, distance)
+            v, err := InverseVincenty(start, p)
+            if err != nil {
+                t.Fatal(err)
+            }
+            az, _ := angDiff(bearing, v.InitialBearing)
+            fz, _ := angDiff(final, v.FinalBearing)
+            if math.Abs(v.Distance-distance) > 1e-6 || math.Abs(az) > 1e-6 || math.Abs(fz) > 1e-6 {
+                t.Errorf("bearing %v distance %v: Vincenty inverse %+v, final %v", bearing, distance, v, final)
+            }
+        }
+    }
+}
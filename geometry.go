@@ -23,54 +23,10 @@ func IsPointInPolygon(p Point, polygon []Point) bool {
 }
 
 // FilterPointsInPolygonConcurrent filters points inside polygon concurrently
+// Convenience wrapper around FilterPointsConcurrent for a polygon without holes
 // points: Slice of points to filter
 // polygon: Polygon vertices
-// Returns: Points located inside the polygon
+// Returns: Points located inside the polygon, in input order
 func FilterPointsInPolygonConcurrent(points []Point, polygon []Point) []Point {
-    type result struct {
-        index int
-        valid bool
-    }
-
-    results := make(chan result, len(points))
-    var wg sync.WaitGroup
-
-    // Determine batch size
-    batchSize := 1000
-    if len(points) < 1000 {
-        batchSize = len(points)
-    }
-
-    // Process points in batches
-    for i := 0; i < len(points); i += batchSize {
-        end := i + batchSize
-        if end > len(points) {
-            end = len(points)
-        }
-
-        wg.Add(1)
-        go func(start, end int) {
-            defer wg.Done()
-            for j := start; j < end; j++ {
-                valid := IsPointInPolygon(points[j], polygon)
-                results <- result{j, valid}
-            }
-        }(i, end)
-    }
-
-    // Close results when done
-    go func() {
-        wg.Wait()
-        close(results)
-    }()
-
-    // Collect valid points
-    filtered := make([]Point, 0, len(points))
-    for res := range results {
-        if res.valid {
-            filtered = append(filtered, points[res.index])
-        }
-    }
-
-    return filtered
+    return FilterPointsConcurrent(points, Polygon{Outer: polygon})
 }
\ No newline at end of file
@@ -0,0 +1,366 @@
+package geoutil
+
+import (
+    "math"
+    "sort"
+)
+
+// ConcaveHullOptions configures ConcaveHull
+type ConcaveHullOptions struct {
+    Concavity       float64 // Dig an edge when length / distance to the inner point exceeds this (default 2, larger is more convex)
+    LengthThreshold float64 // Edges shorter than this many meters are not dug (default 0)
+}
+
+// ConvexHull computes the convex hull of a point set
+// Uses Andrew's monotone chain on a local equirectangular projection, which is
+// affine in lat/lon, so every input point is inside or on the hull for IsPointInPolygon
+// Hulls crossing the antimeridian need ContainmentAntimeridian
+// points: Input points, e.g. delivered orders
+// Returns: Counter-clockwise open ring of input points (fewer than 3 if all are collinear)
+func ConvexHull(points []Point) []Point {
+    pts, proj := uniqueProjected(points)
+    hull := convexHullIndexes(proj)
+    out := make([]Point, len(hull))
+    for i, k := range hull {
+        out[i] = pts[k]
+    }
+    return out
+}
+
+// ConcaveHull computes a concave outline of a point set by digging into convex hull edges
+// Each edge is replaced by two edges through the nearest inner point when the edge is
+// long relative to that point's distance (Park and Oh, 2012), without creating crossings
+// Inner points and hull edges are kept in a uniform grid, so each dig only visits the
+// neighborhood of its edge; long edges over dense clusters still scan many points
+// points: Input points
+// opts: Concavity and minimum edge length
+// Returns: Counter-clockwise open ring of input points enclosing every input point
+func ConcaveHull(points []Point, opts ConcaveHullOptions) []Point {
+    if opts.Concavity <= 0 {
+        opts.Concavity = 2
+    }
+    pts, proj := uniqueProjected(points)
+    hull := convexHullIndexes(proj)
+    if len(hull) < 3 {
+        out := make([]Point, len(hull))
+        for i, k := range hull {
+            out[i] = pts[k]
+        }
+        return out
+    }
+
+    // Circular linked list of hull vertices
+    next := make([]int, len(proj))
+    prev := make([]int, len(proj))
+    onHull := make([]bool, len(proj))
+    for i, k := range hull {
+        next[k] = hull[(i+1)%len(hull)]
+        prev[k] = hull[(i+len(hull)-1)%len(hull)]
+        onHull[k] = true
+    }
+    grid := newHullGrid(proj)
+    for _, k := range hull {
+        grid.addEdge(k, next[k])
+    }
+
+    type candidate struct {
+        index int
+        dist  float64
+    }
+    queue := append([]int(nil), hull...)
+    for len(queue) > 0 {
+        a := queue[0]
+        queue = queue[1:]
+        if !onHull[a] {
+            continue
+        }
+        b := next[a]
+        length := proj[a].dist(proj[b])
+        if length < opts.LengthThreshold || length == 0 {
+            continue
+        }
+        maxDist := length / opts.Concavity
+
+        // Inner points nearer to this edge than to its neighbors, closest first
+        // Candidates lie within maxDist of the edge, so only nearby cells are visited
+        var cands []candidate
+        box := segmentBox(proj[a], proj[b], maxDist)
+        grid.eachPoint(box, func(k int) {
+            if onHull[k] {
+                return
+            }
+            p := proj[k]
+            d := p.segmentDist(proj[a], proj[b])
+            if d > maxDist || math.Min(p.dist(proj[a]), p.dist(proj[b])) > maxDist {
+                return
+            }
+            if p.segmentDist(proj[prev[a]], proj[a]) <= d || p.segmentDist(proj[b], proj[next[b]]) <= d {
+                return
+            }
+            cands = append(cands, candidate{k, d})
+        })
+        sort.Slice(cands, func(i, j int) bool {
+            if cands[i].dist != cands[j].dist {
+                return cands[i].dist < cands[j].dist
+            }
+            return cands[i].index < cands[j].index
+        })
+
+        for _, c := range cands {
+            if grid.crosses(next, a, c.index, a, b) || grid.crosses(next, c.index, b, a, b) ||
+                grid.triangleHasPoint(onHull, a, c.index, b) {
+                continue
+            }
+            grid.removeEdge(a, b)
+            next[a], prev[c.index] = c.index, a
+            next[c.index], prev[b] = b, c.index
+            onHull[c.index] = true
+            grid.addEdge(a, c.index)
+            grid.addEdge(c.index, b)
+            queue = append(queue, a, c.index)
+            break
+        }
+    }
+
+    out := []Point{pts[hull[0]]}
+    for k := next[hull[0]]; k != hull[0]; k = next[k] {
+        out = append(out, pts[k])
+    }
+    return out
+}
+
+// hullGrid buckets inner points and hull edges into uniform cells of the projection
+// Points stay in their cells after joining the hull; callers skip them with onHull
+type hullGrid struct {
+    proj       []planePoint
+    minX, minY float64
+    cell       float64
+    maxKey     [2]int           // Last cell in each direction
+    points     map[[2]int][]int // Point indexes by cell
+    edges      map[[2]int][]int // Hull edges u -> next[u] by cell, keyed by u
+}
+
+// newHullGrid indexes every projected point, with about one point per cell
+func newHullGrid(proj []planePoint) *hullGrid {
+    box := emptyRect()
+    for _, p := range proj {
+        box = box.union(rect{p.y, p.x, p.y, p.x})
+    }
+    g := &hullGrid{
+        proj:   proj,
+        minX:   box.minLon,
+        minY:   box.minLat,
+        points: make(map[[2]int][]int),
+        edges:  make(map[[2]int][]int),
+    }
+    g.cell = math.Max(box.maxLon-box.minLon, box.maxLat-box.minLat) / math.Max(1, math.Sqrt(float64(len(proj))))
+    if !(g.cell > 0) {
+        g.cell = 1
+    }
+    g.maxKey = g.key(planePoint{box.maxLon, box.maxLat})
+    for k, p := range proj {
+        key := g.key(p)
+        g.points[key] = append(g.points[key], k)
+    }
+    return g
+}
+
+// segmentBox returns the bounding box of segment a-b grown by margin, with x as Lon and y as Lat
+func segmentBox(a, b planePoint, margin float64) rect {
+    return rect{
+        minLat: math.Min(a.y, b.y) - margin, minLon: math.Min(a.x, b.x) - margin,
+        maxLat: math.Max(a.y, b.y) + margin, maxLon: math.Max(a.x, b.x) + margin,
+    }
+}
+
+func (g *hullGrid) key(p planePoint) [2]int {
+    return [2]int{int(math.Floor((p.x - g.minX) / g.cell)), int(math.Floor((p.y - g.minY) / g.cell))}
+}
+
+// eachCell calls fn for every cell overlapping box within the indexed extent
+func (g *hullGrid) eachCell(box rect, fn func(key [2]int)) {
+    lo := g.key(planePoint{box.minLon, box.minLat})
+    hi := g.key(planePoint{box.maxLon, box.maxLat})
+    for x := max(lo[0], 0); x <= min(hi[0], g.maxKey[0]); x++ {
+        for y := max(lo[1], 0); y <= min(hi[1], g.maxKey[1]); y++ {
+            fn([2]int{x, y})
+        }
+    }
+}
+
+// eachPoint calls fn for every indexed point in the cells overlapping box
+func (g *hullGrid) eachPoint(box rect, fn func(k int)) {
+    g.eachCell(box, func(key [2]int) {
+        for _, k := range g.points[key] {
+            fn(k)
+        }
+    })
+}
+
+// addEdge indexes hull edge u-v under u
+func (g *hullGrid) addEdge(u, v int) {
+    g.eachCell(segmentBox(g.proj[u], g.proj[v], 0), func(key [2]int) {
+        g.edges[key] = append(g.edges[key], u)
+    })
+}
+
+// removeEdge drops hull edge u-v from the index
+func (g *hullGrid) removeEdge(u, v int) {
+    g.eachCell(segmentBox(g.proj[u], g.proj[v], 0), func(key [2]int) {
+        refs := g.edges[key]
+        for i, w := range refs {
+            if w == u {
+                refs[i] = refs[len(refs)-1]
+                g.edges[key] = refs[:len(refs)-1]
+                break
+            }
+        }
+    })
+}
+
+// crosses reports whether segment p-q crosses a hull edge other than those at a or b
+func (g *hullGrid) crosses(next []int, p, q, a, b int) bool {
+    pp, pq := g.proj[p].point(), g.proj[q].point()
+    found := false
+    g.eachCell(segmentBox(g.proj[p], g.proj[q], 0), func(key [2]int) {
+        for _, u := range g.edges[key] {
+            if found {
+                return
+            }
+            v := next[u]
+            if u == a || u == b || v == a || v == b || u == p || u == q || v == p || v == q {
+                continue
+            }
+            found = segmentsIntersect(pp, pq, g.proj[u].point(), g.proj[v].point())
+        }
+    })
+    return found
+}
+
+// triangleHasPoint reports whether an inner point other than c lies inside triangle a, c, b
+// Digging through c would leave such a point outside the hull
+func (g *hullGrid) triangleHasPoint(onHull []bool, a, c, b int) bool {
+    pa, pc, pb := g.proj[a].point(), g.proj[c].point(), g.proj[b].point()
+    sign := orient(pa, pc, pb)
+    box := segmentBox(g.proj[a], g.proj[b], 0).union(segmentBox(g.proj[c], g.proj[c], 0))
+    found := false
+    g.eachPoint(box, func(k int) {
+        if found || onHull[k] || k == c {
+            return
+        }
+        q := g.proj[k].point()
+        found = orient(pa, pc, q)*sign > 0 && orient(pc, pb, q)*sign > 0 && orient(pb, pa, q)*sign > 0
+    })
+    return found
+}
+
+// convexHullIndexes runs the monotone chain and returns counter-clockwise vertex indexes
+func convexHullIndexes(proj []planePoint) []int {
+    idx := make([]int, len(proj))
+    for i := range idx {
+        idx[i] = i
+    }
+    if len(idx) < 3 {
+        return idx
+    }
+    sort.Slice(idx, func(i, j int) bool {
+        a, b := proj[idx[i]], proj[idx[j]]
+        return a.x < b.x || (a.x == b.x && a.y < b.y)
+    })
+
+    cross := func(o, a, b planePoint) float64 {
+        return (a.x-o.x)*(b.y-o.y) - (a.y-o.y)*(b.x-o.x)
+    }
+    hull := make([]int, 0, 2*len(idx))
+    // Lower hull, then upper hull
+    for _, k := range idx {
+        for len(hull) >= 2 && cross(proj[hull[len(hull)-2]], proj[hull[len(hull)-1]], proj[k]) <= 0 {
+            hull = hull[:len(hull)-1]
+        }
+        hull = append(hull, k)
+    }
+    lower := len(hull) + 1
+    for i := len(idx) - 2; i >= 0; i-- {
+        k := idx[i]
+        for len(hull) >= lower && cross(proj[hull[len(hull)-2]], proj[hull[len(hull)-1]], proj[k]) <= 0 {
+            hull = hull[:len(hull)-1]
+        }
+        hull = append(hull, k)
+    }
+    return hull[:len(hull)-1]
+}
+
+// uniqueProjected removes duplicate points and projects the rest
+func uniqueProjected(points []Point) ([]Point, []planePoint) {
+    seen := make(map[Point]bool, len(points))
+    pts := make([]Point, 0, len(points))
+    for _, p := range points {
+        if !seen[p] {
+            seen[p] = true
+            pts = append(pts, p)
+        }
+    }
+    lp := newLocalProjection(pts)
+    proj := make([]planePoint, len(pts))
+    for i, p := range pts {
+        proj[i] = lp.forward(p)
+    }
+    return pts, proj
+}
+
+// planePoint is a position in a local projection, in meters
+type planePoint struct {
+    x, y float64
+}
+
+func (p planePoint) dist(q planePoint) float64 {
+    return math.Hypot(p.x-q.x, p.y-q.y)
+}
+
+// segmentDist returns the distance from p to segment a-b
+func (p planePoint) segmentDist(a, b planePoint) float64 {
+    dx, dy := b.x-a.x, b.y-a.y
+    t := 0.0
+    if l2 := dx*dx + dy*dy; l2 > 0 {
+        t = math.Max(0, math.Min(1, ((p.x-a.x)*dx+(p.y-a.y)*dy)/l2))
+    }
+    return math.Hypot(a.x+t*dx-p.x, a.y+t*dy-p.y)
+}
+
+// point returns p as a Point with x as Lon and y as Lat for planar predicates
+func (p planePoint) point() Point {
+    return Point{Lat: p.y, Lon: p.x}
+}
+
+// localProjection is an equirectangular projection centered on a set of points
+// Longitudes are unwrapped around the center, so sets crossing ±180° stay contiguous
+type localProjection struct {
+    lat0, lon0 float64
+    kx, ky     float64 // Meters per degree of longitude and latitude
+}
+
+// newLocalProjection centers a projection on the mean latitude and circular mean longitude
+func newLocalProjection(points []Point) localProjection {
+    var lat, sx, sy float64
+    for _, p := range points {
+        lat += p.Lat
+        s, c := math.Sincos(p.Lon * degToRad)
+        sx += c
+        sy += s
+    }
+    lp := localProjection{}
+    if len(points) > 0 {
+        lp.lat0 = lat / float64(len(points))
+        lp.lon0 = math.Atan2(sy, sx) * radToDeg
+    }
+    lp.ky = EarthSphere.Radius * 1000 * degToRad
+    lp.kx = lp.ky * math.Cos(lp.lat0*degToRad)
+    return lp
+}
+
+func (lp localProjection) forward(p Point) planePoint {
+    return planePoint{
+        x: angNormalize(p.Lon-lp.lon0) * lp.kx,
+        y: (p.Lat - lp.lat0) * lp.ky,
+    }
+}
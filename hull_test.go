@@ -0,0 +1,31 @@
+package geoutil
+
+import (
+    "math/rand"
+    "testing"
+)
+
+func TestConcaveHullEnclosesPoints(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, concavity := range []float64{1, 2, 3} {
+        pts := randomPoints(rng, Point{55.75, 37.6}, 2000, 0.3)
+        hull := ConcaveHull(pts, ConcaveHullOptions{Concavity: concavity})
+        if len(hull) < 3 {
+            t.Fatalf("concavity %v: hull has %d vertices", concavity, len(hull))
+        }
+        for _, p := range pts {
+            if ClassifyPoint(p, hull, 0) == Outside {
+                t.Fatalf("concavity %v: point %v outside the hull", concavity, p)
+            }
+        }
+    }
+}
+
+// BenchmarkConcaveHull outlines 100k scattered points, e.g. delivered orders in a city
+func BenchmarkConcaveHull(b *testing.B) {
+    pts := randomPoints(rand.New(rand.NewSource(1)), Point{55.75, 37.6}, 100000, 0.3)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        ConcaveHull(pts, ConcaveHullOptions{})
+    }
+}
@@ -0,0 +1,214 @@
+package geoutil
+
+import (
+    "container/heap"
+    "context"
+    "math"
+    "sort"
+)
+
+// Neighbor is a point returned by a spatial index query
+type Neighbor struct {
+    Index    int     // Position of the point in the indexed slice
+    Point    Point   // Indexed point
+    Distance float64 // Haversine distance from the query point in kilometers
+}
+
+// PointIndex is a vantage-point tree answering nearest-neighbor and radius queries
+// Distances use DistanceHaversine; as a metric it lets the tree prune without missing points
+type PointIndex struct {
+    points []Point
+    nodes  []vpNode
+    root   int
+}
+
+type vpNode struct {
+    index   int     // Vantage point index into points
+    radius  float64 // Median distance splitting inside/outside children
+    inside  int     // Child node with distance <= radius, -1 if none
+    outside int     // Child node with distance >= radius, -1 if none
+}
+
+// NewPointIndex builds a spatial index over points
+// points: Points to index; the slice is not modified
+// Returns: Index, or ErrInvalidCoordinate naming the first invalid point
+func NewPointIndex(points []Point) (*PointIndex, error) {
+    // NaN distances would break the median split and the pruning bounds
+    if err := validatePoints(points); err != nil {
+        return nil, err
+    }
+    ix := &PointIndex{
+        points: points,
+        nodes:  make([]vpNode, 0, len(points)),
+    }
+    items := make([]int, len(points))
+    for i := range items {
+        items[i] = i
+    }
+    dist := make([]float64, len(points))
+    ix.root = ix.build(items, dist)
+    return ix, nil
+}
+
+// build creates the subtree for items and returns its node number
+func (ix *PointIndex) build(items []int, dist []float64) int {
+    if len(items) == 0 {
+        return -1
+    }
+
+    // Use the middle item as vantage point to avoid degenerate trees on sorted input
+    mid := len(items) / 2
+    items[0], items[mid] = items[mid], items[0]
+    node := len(ix.nodes)
+    ix.nodes = append(ix.nodes, vpNode{index: items[0], inside: -1, outside: -1})
+    rest := items[1:]
+    if len(rest) == 0 {
+        return node
+    }
+
+    vp := ix.points[items[0]]
+    for _, i := range rest {
+        dist[i] = DistanceHaversine(vp, ix.points[i])
+    }
+    sort.Slice(rest, func(a, b int) bool { return dist[rest[a]] < dist[rest[b]] })
+
+    // Split by position so duplicates and equal distances still halve the items;
+    // items at exactly the median distance may end up on either side
+    split := len(rest) / 2
+    radius := dist[rest[split]]
+
+    inside := ix.build(rest[:split], dist)
+    outside := ix.build(rest[split:], dist)
+    ix.nodes[node].radius = radius
+    ix.nodes[node].inside = inside
+    ix.nodes[node].outside = outside
+    return node
+}
+
+// Len returns the number of indexed points
+func (ix *PointIndex) Len() int {
+    return len(ix.points)
+}
+
+// Nearest finds the k points closest to p
+// p: Query point
+// k: Number of neighbors
+// Returns: Up to k neighbors ordered by increasing distance
+func (ix *PointIndex) Nearest(p Point, k int) []Neighbor {
+    if k <= 0 || ix.root < 0 {
+        return nil
+    }
+    h := &neighborHeap{}
+    ix.searchNearest(ix.root, p, k, h)
+
+    result := make([]Neighbor, h.Len())
+    for i := len(result) - 1; i >= 0; i-- {
+        result[i] = heap.Pop(h).(Neighbor)
+    }
+    return result
+}
+
+// searchNearest walks the tree keeping the k best candidates in a max-heap
+func (ix *PointIndex) searchNearest(n int, p Point, k int, h *neighborHeap) {
+    if n < 0 {
+        return
+    }
+    node := ix.nodes[n]
+    d := DistanceHaversine(p, ix.points[node.index])
+    if h.Len() < k {
+        heap.Push(h, Neighbor{node.index, ix.points[node.index], d})
+    } else if d < (*h)[0].Distance {
+        (*h)[0] = Neighbor{node.index, ix.points[node.index], d}
+        heap.Fix(h, 0)
+    }
+
+    // tau is the current search radius; it shrinks as better candidates are found
+    tau := func() float64 {
+        if h.Len() < k {
+            return math.Inf(1)
+        }
+        return (*h)[0].Distance
+    }
+    if d < node.radius {
+        if d-tau() <= node.radius {
+            ix.searchNearest(node.inside, p, k, h)
+        }
+        if d+tau() >= node.radius {
+            ix.searchNearest(node.outside, p, k, h)
+        }
+    } else {
+        if d+tau() >= node.radius {
+            ix.searchNearest(node.outside, p, k, h)
+        }
+        if d-tau() <= node.radius {
+            ix.searchNearest(node.inside, p, k, h)
+        }
+    }
+}
+
+// WithinRadius finds all points within a distance of p
+// p: Query point
+// radius: Search radius in kilometers (inclusive)
+// Returns: Neighbors ordered by increasing distance
+func (ix *PointIndex) WithinRadius(p Point, radius float64) []Neighbor {
+    var result []Neighbor
+    ix.searchRadius(ix.root, p, radius, &result)
+    sort.Slice(result, func(i, j int) bool { return result[i].Distance < result[j].Distance })
+    return result
+}
+
+// searchRadius collects points within radius of p
+func (ix *PointIndex) searchRadius(n int, p Point, radius float64, result *[]Neighbor) {
+    if n < 0 {
+        return
+    }
+    node := ix.nodes[n]
+    d := DistanceHaversine(p, ix.points[node.index])
+    if d <= radius {
+        *result = append(*result, Neighbor{node.index, ix.points[node.index], d})
+    }
+    if d-radius <= node.radius {
+        ix.searchRadius(node.inside, p, radius, result)
+    }
+    if d+radius >= node.radius {
+        ix.searchRadius(node.outside, p, radius, result)
+    }
+}
+
+// BatchNearest runs Nearest for many query points concurrently
+// queries: Query points
+// k: Number of neighbors per query
+// Returns: Neighbors for each query in input order
+func (ix *PointIndex) BatchNearest(queries []Point, k int) [][]Neighbor {
+    results := make([][]Neighbor, len(queries))
+    forEachRow(context.Background(), len(queries), MatrixOptions{}.withDefaults(len(queries)), func(i int) {
+        results[i] = ix.Nearest(queries[i], k)
+    })
+    return results
+}
+
+// BatchWithinRadius runs WithinRadius for many query points concurrently
+// queries: Query points
+// radius: Search radius in kilometers (inclusive)
+// Returns: Neighbors for each query in input order
+func (ix *PointIndex) BatchWithinRadius(queries []Point, radius float64) [][]Neighbor {
+    results := make([][]Neighbor, len(queries))
+    forEachRow(context.Background(), len(queries), MatrixOptions{}.withDefaults(len(queries)), func(i int) {
+        results[i] = ix.WithinRadius(queries[i], radius)
+    })
+    return results
+}
+
+// neighborHeap is a max-heap of neighbors by distance
+type neighborHeap []Neighbor
+
+func (h neighborHeap) Len() int           { return len(h) }
+func (h neighborHeap) Less(i, j int) bool { return h[i].Distance > h[j].Distance }
+func (h neighborHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x any)        { *h = append(*h, x.(Neighbor)) }
+func (h *neighborHeap) Pop() any {
+    old := *h
+    n := old[len(old)-1]
+    *h = old[:len(old)-1]
+    return n
+}
\ No newline at end of file
@@ -0,0 +1,168 @@
+package geoutil
+
+import (
+    "errors"
+    "math"
+    "math/rand"
+    "sort"
+    "testing"
+)
+
+// bruteNeighbors returns every point within radius of p ordered by distance and index
+func bruteNeighbors(points []Point, p Point, radius float64) []Neighbor {
+    var out []Neighbor
+    for i, q := range points {
+        if d := DistanceHaversine(p, q); d <= radius {
+            out = append(out, Neighbor{i, q, d})
+        }
+    }
+    sortNeighbors(out)
+    return out
+}
+
+// sortNeighbors orders neighbors by distance, breaking ties by index
+func sortNeighbors(ns []Neighbor) {
+    sort.Slice(ns, func(i, j int) bool {
+        if ns[i].Distance != ns[j].Distance {
+            return ns[i].Distance < ns[j].Distance
+        }
+        return ns[i].Index < ns[j].Index
+    })
+}
+
+// depth returns the height of the subtree rooted at node n
+func (ix *PointIndex) depth(n int) int {
+    if n < 0 {
+        return 0
+    }
+    return 1 + max(ix.depth(ix.nodes[n].inside), ix.depth(ix.nodes[n].outside))
+}
+
+// indexTestSets returns point sets including duplicates and rings of equal distances
+func indexTestSets() map[string][]Point {
+    rng := rand.New(rand.NewSource(3))
+    sets := map[string][]Point{
+        "random":    randomPoints(rng, Point{48.85, 2.35}, 2000, 3),
+        "worldwide": randomPoints(rng, Point{0, 0}, 2000, 89),
+        "identical": make([]Point, 1000),
+    }
+    for i := range sets["identical"] {
+        sets["identical"][i] = Point{55.75, 37.62}
+    }
+    // Few distinct locations, many copies of each
+    var dup []Point
+    for _, p := range randomPoints(rng, Point{40, -74}, 20, 0.5) {
+        for range 50 {
+            dup = append(dup, p)
+        }
+    }
+    sets["duplicates"] = dup
+    // A grid has many points at exactly equal distances from each vantage point
+    var grid []Point
+    for lat := -10; lat <= 10; lat++ {
+        for lon := 170; lon <= 190; lon++ {
+            grid = append(grid, Point{float64(lat), angNormalize(float64(lon))})
+        }
+    }
+    sets["grid across antimeridian"] = grid
+    return sets
+}
+
+func TestPointIndexMatchesBruteForce(t *testing.T) {
+    rng := rand.New(rand.NewSource(4))
+    for name, points := range indexTestSets() {
+        t.Run(name, func(t *testing.T) {
+            ix, err := NewPointIndex(points)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if ix.Len() != len(points) {
+                t.Errorf("Len() = %d, want %d", ix.Len(), len(points))
+            }
+            queries := append(randomPoints(rng, points[0], 50, 2), points[:10]...)
+            for _, q := range queries {
+                for _, radius := range []float64{0, 1, 50, 500} {
+                    got := ix.WithinRadius(q, radius)
+                    sortNeighbors(got)
+                    want := bruteNeighbors(points, q, radius)
+                    if len(got) != len(want) {
+                        t.Fatalf("WithinRadius(%v, %v): got %d points, want %d", q, radius, len(got), len(want))
+                    }
+                    for i := range got {
+                        if got[i] != want[i] {
+                            t.Fatalf("WithinRadius(%v, %v)[%d] = %+v, want %+v", q, radius, i, got[i], want[i])
+                        }
+                    }
+                }
+
+                all := bruteNeighbors(points, q, math.Inf(1))
+                for _, k := range []int{1, 5, 60} {
+                    got := ix.Nearest(q, k)
+                    if len(got) != min(k, len(points)) {
+                        t.Fatalf("Nearest(%v, %d): got %d points", q, k, len(got))
+                    }
+                    // Ties may be broken either way, so compare distances and check each point
+                    for i, n := range got {
+                        if n.Distance != all[i].Distance {
+                            t.Fatalf("Nearest(%v, %d)[%d] distance %v, want %v", q, k, i, n.Distance, all[i].Distance)
+                        }
+                        if points[n.Index] != n.Point || DistanceHaversine(q, n.Point) != n.Distance {
+                            t.Fatalf("Nearest(%v, %d)[%d] = %+v is inconsistent", q, k, i, n)
+                        }
+                    }
+                }
+            }
+        })
+    }
+}
+
+func TestPointIndexBalancedWithDuplicates(t *testing.T) {
+    points := make([]Point, 1<<14)
+    for i := range points {
+        points[i] = Point{float64(i % 3), 0}
+    }
+    ix, err := NewPointIndex(points)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // Splitting by position keeps the tree logarithmic instead of a chain
+    if d := ix.depth(ix.root); d > 2*15 {
+        t.Errorf("tree depth %d for %d points, want about log2(n)", d, len(points))
+    }
+    if got := ix.WithinRadius(Point{0, 0}, 0); len(got) != (len(points)+2)/3 {
+        t.Errorf("WithinRadius found %d copies, want %d", len(got), (len(points)+2)/3)
+    }
+}
+
+func TestPointIndexRejectsInvalidPoints(t *testing.T) {
+    for _, p := range []Point{{math.NaN(), 0}, {0, math.NaN()}, {91, 0}, {0, math.Inf(1)}} {
+        ix, err := NewPointIndex([]Point{{0, 0}, p})
+        if !errors.Is(err, ErrInvalidCoordinate) || ix != nil {
+            t.Errorf("NewPointIndex with %v: got %v, %v; want ErrInvalidCoordinate", p, ix, err)
+        }
+    }
+    ix, err := NewPointIndex(nil)
+    if err != nil || ix.Len() != 0 || ix.Nearest(Point{0, 0}, 3) != nil || len(ix.WithinRadius(Point{0, 0}, 10)) != 0 {
+        t.Errorf("empty index: got %v, %v", ix, err)
+    }
+}
+
+func TestPointIndexBatch(t *testing.T) {
+    rng := rand.New(rand.NewSource(5))
+    points := randomPoints(rng, Point{35.68, 139.69}, 500, 1)
+    ix, err := NewPointIndex(points)
+    if err != nil {
+        t.Fatal(err)
+    }
+    queries := randomPoints(rng, Point{35.68, 139.69}, 40, 1)
+    nearest := ix.BatchNearest(queries, 3)
+    within := ix.BatchWithinRadius(queries, 10)
+    for i, q := range queries {
+        if want := ix.Nearest(q, 3); len(nearest[i]) != len(want) || nearest[i][0] != want[0] {
+            t.Errorf("BatchNearest[%d] = %v, want %v", i, nearest[i], want)
+        }
+        if want := ix.WithinRadius(q, 10); len(within[i]) != len(want) {
+            t.Errorf("BatchWithinRadius[%d]: got %d, want %d", i, len(within[i]), len(want))
+        }
+    }
+}
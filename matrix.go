@@ -0,0 +1,182 @@
+package geoutil
+
+import (
+    "context"
+    "fmt"
+    "runtime"
+    "sync"
+    "sync/atomic"
+)
+
+// MatrixOptions configures distance matrix computation
+type MatrixOptions struct {
+    Workers   int  // Worker goroutines (default runtime.NumCPU())
+    ChunkRows int  // Rows claimed by a worker at a time (default chosen from size and workers)
+    Float32   bool // Store values as float32 to halve memory usage
+}
+
+// withDefaults fills unset options for a matrix with the given number of rows
+func (o MatrixOptions) withDefaults(rows int) MatrixOptions {
+    if o.Workers <= 0 {
+        o.Workers = runtime.NumCPU()
+    }
+    if o.ChunkRows <= 0 {
+        // Several chunks per worker keep triangular workloads balanced
+        o.ChunkRows = max(1, rows/(o.Workers*16))
+    }
+    return o
+}
+
+// DistanceMatrix is a dense distance matrix with flat row-major storage
+type DistanceMatrix struct {
+    Rows   int       // Number of rows (origins)
+    Cols   int       // Number of columns (destinations)
+    Data   []float64 // Row-major values, nil when stored as float32
+    Data32 []float32 // Row-major values, nil when stored as float64
+}
+
+// newDistanceMatrix allocates storage for a rows×cols matrix
+func newDistanceMatrix(rows, cols int, compact bool) *DistanceMatrix {
+    m := &DistanceMatrix{Rows: rows, Cols: cols}
+    if compact {
+        m.Data32 = make([]float32, rows*cols)
+    } else {
+        m.Data = make([]float64, rows*cols)
+    }
+    return m
+}
+
+// At returns the value at row i, column j
+func (m *DistanceMatrix) At(i, j int) float64 {
+    if m.Data32 != nil {
+        return float64(m.Data32[i*m.Cols+j])
+    }
+    return m.Data[i*m.Cols+j]
+}
+
+// set stores the value at row i, column j
+func (m *DistanceMatrix) set(i, j int, v float64) {
+    if m.Data32 != nil {
+        m.Data32[i*m.Cols+j] = float32(v)
+        return
+    }
+    m.Data[i*m.Cols+j] = v
+}
+
+// ToSlices returns the matrix as row slices
+// float64 storage is shared with the result, float32 storage is converted
+func (m *DistanceMatrix) ToSlices() [][]float64 {
+    rows := make([][]float64, m.Rows)
+    if m.Data32 == nil {
+        for i := range rows {
+            rows[i] = m.Data[i*m.Cols : (i+1)*m.Cols : (i+1)*m.Cols]
+        }
+        return rows
+    }
+    for i := range rows {
+        row := make([]float64, m.Cols)
+        for j := range row {
+            row[j] = float64(m.Data32[i*m.Cols+j])
+        }
+        rows[i] = row
+    }
+    return rows
+}
+
+// ComputeDistanceMatrix calculates a symmetric NxN distance matrix
+// Only the upper triangle is evaluated; the diagonal is zero
+// ctx: Context for cancellation, checked between rows
+// points: Slice of geographic points
+// distanceFunc: Distance calculation function
+// opts: Worker, chunking and storage options
+// Returns: Distance matrix, ErrInvalidCoordinate or context error
+func ComputeDistanceMatrix(ctx context.Context, points []Point, distanceFunc func(p1, p2 Point) float64, opts MatrixOptions) (*DistanceMatrix, error) {
+    if err := validatePoints(points); err != nil {
+        return nil, err
+    }
+    return computeDistanceMatrix(ctx, points, distanceFunc, opts)
+}
+
+// computeDistanceMatrix is ComputeDistanceMatrix without point validation
+func computeDistanceMatrix(ctx context.Context, points []Point, distanceFunc func(p1, p2 Point) float64, opts MatrixOptions) (*DistanceMatrix, error) {
+    n := len(points)
+    m := newDistanceMatrix(n, n, opts.Float32)
+    err := forEachRow(ctx, n, opts.withDefaults(n), func(i int) {
+        for j := i + 1; j < n; j++ {
+            dist := distanceFunc(points[i], points[j])
+            // Row i is the only writer of both (i, j) and (j, i) for j > i
+            m.set(i, j, dist)
+            m.set(j, i, dist)
+        }
+    })
+    if err != nil {
+        return nil, err
+    }
+    return m, nil
+}
+
+// ComputeDistanceMatrixRect calculates an origins×destinations distance matrix
+// ctx: Context for cancellation, checked between rows
+// origins: Slice of M origin points
+// destinations: Slice of N destination points
+// distanceFunc: Distance calculation function
+// opts: Worker, chunking and storage options
+// Returns: MxN distance matrix, ErrInvalidCoordinate or context error
+func ComputeDistanceMatrixRect(ctx context.Context, origins, destinations []Point, distanceFunc func(p1, p2 Point) float64, opts MatrixOptions) (*DistanceMatrix, error) {
+    if err := validatePoints(origins); err != nil {
+        return nil, fmt.Errorf("origins: %w", err)
+    }
+    if err := validatePoints(destinations); err != nil {
+        return nil, fmt.Errorf("destinations: %w", err)
+    }
+    return computeDistanceMatrixRect(ctx, origins, destinations, distanceFunc, opts)
+}
+
+// computeDistanceMatrixRect is ComputeDistanceMatrixRect without point validation
+func computeDistanceMatrixRect(ctx context.Context, origins, destinations []Point, distanceFunc func(p1, p2 Point) float64, opts MatrixOptions) (*DistanceMatrix, error) {
+    m := newDistanceMatrix(len(origins), len(destinations), opts.Float32)
+    err := forEachRow(ctx, len(origins), opts.withDefaults(len(origins)), func(i int) {
+        for j, dest := range destinations {
+            m.set(i, j, distanceFunc(origins[i], dest))
+        }
+    })
+    if err != nil {
+        return nil, err
+    }
+    return m, nil
+}
+
+// forEachRow runs fn for every row index using workers that claim chunks of rows
+// Returns: Context error if cancelled before all rows were processed
+func forEachRow(ctx context.Context, rows int, opts MatrixOptions, fn func(i int)) error {
+    var next, done atomic.Int64
+    var wg sync.WaitGroup
+
+    workers := min(opts.Workers, max(1, rows))
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for ctx.Err() == nil {
+                start := int(next.Add(int64(opts.ChunkRows))) - opts.ChunkRows
+                if start >= rows {
+                    return
+                }
+                end := min(start+opts.ChunkRows, rows)
+                for i := start; i < end; i++ {
+                    if ctx.Err() != nil {
+                        return
+                    }
+                    fn(i)
+                    done.Add(1)
+                }
+            }
+        }()
+    }
+
+    wg.Wait()
+    if int(done.Load()) == rows {
+        return nil
+    }
+    return ctx.Err()
+}
\ No newline at end of file
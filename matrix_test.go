@@ -0,0 +1,206 @@
+package geoutil
+
+import (
+    "context"
+    "errors"
+    "math"
+    "math/rand"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestDistanceMatrixRectShapes(t *testing.T) {
+    rng := rand.New(rand.NewSource(17))
+    warehouses := randomPoints(rng, Point{52, 13}, 7, 2)
+    customers := randomPoints(rng, Point{52, 13}, 23, 2)
+    tests := []struct {
+        name                  string
+        origins, destinations []Point
+    }{
+        {"wide", warehouses, customers},
+        {"tall", customers, warehouses},
+        {"single origin", warehouses[:1], customers},
+        {"single destination", customers, warehouses[:1]},
+        {"no origins", nil, customers},
+        {"no destinations", warehouses, nil},
+        {"both empty", nil, nil},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            rows := DistanceMatrixConcurrent(tt.origins, tt.destinations, DistanceHaversine)
+            m, err := ComputeDistanceMatrixRect(context.Background(), tt.origins, tt.destinations, DistanceHaversine, MatrixOptions{Workers: 3})
+            if err != nil {
+                t.Fatal(err)
+            }
+            if m.Rows != len(tt.origins) || m.Cols != len(tt.destinations) || len(rows) != len(tt.origins) {
+                t.Fatalf("got %dx%d matrix and %d rows, want %dx%d", m.Rows, m.Cols, len(rows), len(tt.origins), len(tt.destinations))
+            }
+            for i, o := range tt.origins {
+                if len(rows[i]) != len(tt.destinations) {
+                    t.Fatalf("row %d has %d columns, want %d", i, len(rows[i]), len(tt.destinations))
+                }
+                for j, d := range tt.destinations {
+                    want := DistanceHaversine(o, d)
+                    if rows[i][j] != want || m.At(i, j) != want {
+                        t.Fatalf("[%d][%d] = %v and %v, want %v", i, j, rows[i][j], m.At(i, j), want)
+                    }
+                }
+            }
+        })
+    }
+}
+
+func TestDistanceMatrixRectDistanceFunc(t *testing.T) {
+    // Origins index rows and destinations columns, even for asymmetric functions
+    origins := []Point{{0, 0}, {1, 0}}
+    destinations := []Point{{0, 1}, {0, 2}, {0, 3}}
+    latMinusLon := func(p1, p2 Point) float64 { return p1.Lat - p2.Lon }
+    rows := DistanceMatrixConcurrent(origins, destinations, latMinusLon)
+    for i := range origins {
+        for j := range destinations {
+            if want := origins[i].Lat - destinations[j].Lon; rows[i][j] != want {
+                t.Errorf("[%d][%d] = %v, want %v", i, j, rows[i][j], want)
+            }
+        }
+    }
+}
+
+func TestDistanceMatrixRectInvalidPoints(t *testing.T) {
+    good := []Point{{0, 0}, {1, 1}}
+    bad := []Point{{0, 0}, {0, math.Inf(1)}}
+    if _, err := ComputeDistanceMatrixRect(context.Background(), bad, good, DistanceHaversine, MatrixOptions{}); !errors.Is(err, ErrInvalidCoordinate) {
+        t.Errorf("invalid origin: err = %v, want ErrInvalidCoordinate", err)
+    }
+    if _, err := ComputeDistanceMatrixRect(context.Background(), good, bad, DistanceHaversine, MatrixOptions{}); !errors.Is(err, ErrInvalidCoordinate) {
+        t.Errorf("invalid destination: err = %v, want ErrInvalidCoordinate", err)
+    }
+    // The legacy wrapper passes points through
+    if rows := DistanceMatrixConcurrent(good, bad, DistanceHaversine); len(rows) != 2 || !math.IsNaN(rows[0][1]) {
+        t.Errorf("DistanceMatrixConcurrent = %v, want NaN for the infinite destination", rows)
+    }
+}
+
+func TestComputeDistanceMatrixOptions(t *testing.T) {
+    rng := rand.New(rand.NewSource(29))
+    pts := randomPoints(rng, Point{-33, 151}, 97, 5)
+    others := randomPoints(rng, Point{-33, 151}, 13, 5)
+    for _, opts := range []MatrixOptions{
+        {},
+        {Workers: 1},
+        {Workers: 4, ChunkRows: 1},
+        {Workers: 3, ChunkRows: 50},
+        {Workers: 200, ChunkRows: 1000},
+        {Float32: true},
+        {Workers: 5, ChunkRows: 7, Float32: true},
+    } {
+        sym, err := ComputeDistanceMatrix(context.Background(), pts, DistanceHaversine, opts)
+        if err != nil {
+            t.Fatal(err)
+        }
+        rect, err := ComputeDistanceMatrixRect(context.Background(), pts, others, DistanceHaversine, opts)
+        if err != nil {
+            t.Fatal(err)
+        }
+        for _, m := range []*DistanceMatrix{sym, rect} {
+            if opts.Float32 && (m.Data != nil || len(m.Data32) != m.Rows*m.Cols) {
+                t.Fatalf("%+v: float32 matrix has %d float64 and %d float32 values", opts, len(m.Data), len(m.Data32))
+            }
+            if !opts.Float32 && (m.Data32 != nil || len(m.Data) != m.Rows*m.Cols) {
+                t.Fatalf("%+v: float64 matrix has %d float64 and %d float32 values", opts, len(m.Data), len(m.Data32))
+            }
+        }
+        // float32 keeps about 7 significant digits
+        tol := 0.0
+        if opts.Float32 {
+            tol = 1e-6
+        }
+        for i := range pts {
+            if sym.At(i, i) != 0 {
+                t.Fatalf("%+v: diagonal [%d][%d] = %v, want 0", opts, i, i, sym.At(i, i))
+            }
+            for j := range pts {
+                want := DistanceHaversine(pts[min(i, j)], pts[max(i, j)])
+                if got := sym.At(i, j); math.Abs(got-want) > tol*want {
+                    t.Fatalf("%+v: symmetric [%d][%d] = %v, want %v", opts, i, j, got, want)
+                }
+            }
+            for j := range others {
+                want := DistanceHaversine(pts[i], others[j])
+                if got := rect.At(i, j); math.Abs(got-want) > tol*want {
+                    t.Fatalf("%+v: rectangular [%d][%d] = %v, want %v", opts, i, j, got, want)
+                }
+            }
+        }
+        rows := rect.ToSlices()
+        for i := range rows {
+            for j := range rows[i] {
+                if rows[i][j] != rect.At(i, j) {
+                    t.Fatalf("%+v: ToSlices [%d][%d] = %v, want %v", opts, i, j, rows[i][j], rect.At(i, j))
+                }
+            }
+        }
+    }
+}
+
+func TestComputeDistanceMatrixCancelled(t *testing.T) {
+    pts := randomPoints(rand.New(rand.NewSource(31)), Point{0, 0}, 200, 10)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    m, err := ComputeDistanceMatrix(ctx, pts, DistanceHaversine, MatrixOptions{})
+    if m != nil || !errors.Is(err, context.Canceled) {
+        t.Errorf("ComputeDistanceMatrix with cancelled context = %v, %v, want nil, context.Canceled", m, err)
+    }
+    m, err = ComputeDistanceMatrixRect(ctx, pts, pts, DistanceHaversine, MatrixOptions{Float32: true})
+    if m != nil || !errors.Is(err, context.Canceled) {
+        t.Errorf("ComputeDistanceMatrixRect with cancelled context = %v, %v, want nil, context.Canceled", m, err)
+    }
+
+    // Cancelling during the first row stops the workers before the remaining rows
+    for _, opts := range []MatrixOptions{{Workers: 1, ChunkRows: 1}, {Workers: 4, ChunkRows: 16}} {
+        ctx, cancel := context.WithCancel(context.Background())
+        var calls atomic.Int64
+        cancelling := func(p1, p2 Point) float64 {
+            calls.Add(1)
+            cancel()
+            return DistanceHaversine(p1, p2)
+        }
+        m, err := ComputeDistanceMatrixRect(ctx, pts, pts, cancelling, opts)
+        if m != nil || !errors.Is(err, context.Canceled) {
+            t.Errorf("%+v: cancelled mid-run = %v, %v, want nil, context.Canceled", opts, m, err)
+        }
+        // Each worker finishes at most its current row
+        if limit := int64(opts.Workers * len(pts)); calls.Load() > limit {
+            t.Errorf("%+v: %d distance calls after cancellation, want at most %d", opts, calls.Load(), limit)
+        }
+        cancel()
+    }
+
+    // A deadline is reported as such
+    ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+    defer cancel()
+    slow := func(p1, p2 Point) float64 {
+        time.Sleep(time.Millisecond)
+        return DistanceHaversine(p1, p2)
+    }
+    if _, err := ComputeDistanceMatrix(ctx, pts, slow, MatrixOptions{Workers: 2}); !errors.Is(err, context.DeadlineExceeded) {
+        t.Errorf("ComputeDistanceMatrix past deadline: err = %v, want context.DeadlineExceeded", err)
+    }
+}
+
+func TestComputeDistanceMatrixEmpty(t *testing.T) {
+    // Nothing to compute, so even a cancelled context succeeds
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    for _, opts := range []MatrixOptions{{}, {Float32: true}} {
+        m, err := ComputeDistanceMatrix(ctx, nil, DistanceHaversine, opts)
+        if err != nil || m.Rows != 0 || m.Cols != 0 || len(m.ToSlices()) != 0 {
+            t.Errorf("%+v: empty matrix = %+v, %v", opts, m, err)
+        }
+        m, err = ComputeDistanceMatrix(context.Background(), []Point{{1, 2}}, DistanceHaversine, opts)
+        if err != nil || m.Rows != 1 || m.At(0, 0) != 0 {
+            t.Errorf("%+v: single-point matrix = %+v, %v", opts, m, err)
+        }
+    }
+}
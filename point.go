@@ -0,0 +1,101 @@
+package geoutil
+
+import (
+    "errors"
+    "fmt"
+    "math"
+)
+
+// ErrInvalidCoordinate is returned for points that are NaN, infinite or out of range
+// Use errors.As with *CoordinateError to get the point and the reason
+var ErrInvalidCoordinate = errors.New("invalid coordinate")
+
+// CoordinateError describes why a point was rejected
+type CoordinateError struct {
+    Point  Point  // Rejected point
+    Reason string // What is wrong with it
+}
+
+// Error describes the point and the reason
+func (e *CoordinateError) Error() string {
+    return fmt.Sprintf("invalid coordinate (%g, %g): %s", e.Point.Lat, e.Point.Lon, e.Reason)
+}
+
+// Unwrap returns ErrInvalidCoordinate so errors.Is matches every CoordinateError
+func (e *CoordinateError) Unwrap() error {
+    return ErrInvalidCoordinate
+}
+
+// Validate checks that the point is finite with latitude in [-90, 90] and longitude in [-180, 180]
+// Returns: nil or *CoordinateError wrapping ErrInvalidCoordinate
+func (p Point) Validate() error {
+    var reason string
+    switch {
+    case math.IsNaN(p.Lat) || math.IsNaN(p.Lon):
+        reason = "NaN"
+    case math.IsInf(p.Lat, 0) || math.IsInf(p.Lon, 0):
+        reason = "infinite"
+    case p.Lat < -90 || p.Lat > 90:
+        reason = "latitude out of range [-90, 90]"
+        if math.Abs(p.Lon) <= 90 {
+            // A common mistake is passing (lon, lat)
+            reason += ", latitude and longitude may be swapped"
+        }
+    case p.Lon < -180 || p.Lon > 180:
+        reason = "longitude out of range [-180, 180]"
+    default:
+        return nil
+    }
+    return &CoordinateError{Point: p, Reason: reason}
+}
+
+// Normalize brings the point into the valid range
+// Latitudes past a pole are reflected back over it, which moves the point to the
+// opposite meridian; longitudes are then wrapped to (-180, 180]
+// Returns: Equivalent valid point; NaN and infinite coordinates stay invalid
+func (p Point) Normalize() Point {
+    lat := angNormalize(p.Lat)
+    lon := p.Lon
+    if lat > 90 {
+        lat, lon = 180-lat, lon+180
+    } else if lat < -90 {
+        lat, lon = -180-lat, lon+180
+    }
+    lon = angNormalize(lon)
+    if lon == -180 {
+        lon = 180
+    }
+    return Point{Lat: lat, Lon: lon}
+}
+
+// Equal determines if two points are within tolerance of each other
+// Longitudes ±180 and all longitudes at a pole compare equal
+// q: Other point
+// tolerance: Maximum distance in meters, measured with DistanceHaversine
+// Returns: true if the points are at most tolerance apart; false for invalid points
+func (p Point) Equal(q Point, tolerance float64) bool {
+    if !validCoordinate(p) || !validCoordinate(q) {
+        return false
+    }
+    p, q = p.Normalize(), q.Normalize()
+    if p == q || (math.Abs(p.Lat) == 90 && p.Lat == q.Lat) {
+        return true
+    }
+    return DistanceHaversine(p, q)*1000 <= tolerance
+}
+
+// validatePoints checks every point, naming the index of the first invalid one
+func validatePoints(points []Point) error {
+    for i, p := range points {
+        if err := p.Validate(); err != nil {
+            return fmt.Errorf("point %d: %w", i, err)
+        }
+    }
+    return nil
+}
+
+// validCoordinate reports whether p is finite and within the latitude and longitude ranges
+func validCoordinate(p Point) bool {
+    return !math.IsNaN(p.Lat) && !math.IsNaN(p.Lon) &&
+        p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
\ No newline at end of file
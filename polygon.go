@@ -0,0 +1,95 @@
+package geoutil
+
+import "context"
+
+// Container is implemented by shapes that can test point containment
+type Container interface {
+    Contains(p Point) bool
+}
+
+// Polygon is an area bounded by an outer ring with optional holes
+// Rings are open or closed vertex lists in either winding order
+type Polygon struct {
+    Outer []Point         // Outer boundary (at least 3 points)
+    Holes [][]Point       // Rings cut out of the outer area, e.g. lakes inside a park
+    Mode  ContainmentMode // Edge interpretation for all rings (default planar)
+}
+
+// MultiPolygon is a set of polygons treated as one shape, e.g. a country made of islands
+type MultiPolygon []Polygon
+
+var (
+    _ Container = Polygon{}
+    _ Container = MultiPolygon{}
+)
+
+// Contains determines if a point is inside the outer ring and outside every hole
+// p: Point to check
+// Returns: true if point is inside the polygon area
+func (pg Polygon) Contains(p Point) bool {
+    if !IsPointInPolygonMode(p, pg.Outer, pg.Mode) {
+        return false
+    }
+    for _, hole := range pg.Holes {
+        if IsPointInPolygonMode(p, hole, pg.Mode) {
+            return false
+        }
+    }
+    return true
+}
+
+// bounds returns the bounding rectangle of the outer ring
+func (pg Polygon) bounds() rect {
+    return ringBounds(pg.Outer, pg.Mode)
+}
+
+// Contains determines if a point is inside any of the polygons
+// p: Point to check
+// Returns: true if point is inside the multipolygon area
+func (mp MultiPolygon) Contains(p Point) bool {
+    for _, pg := range mp {
+        if pg.Contains(p) {
+            return true
+        }
+    }
+    return false
+}
+
+// bounds returns the bounding rectangle of all outer rings
+func (mp MultiPolygon) bounds() rect {
+    r := emptyRect()
+    for _, pg := range mp {
+        r = r.union(pg.bounds())
+    }
+    return r
+}
+
+// FilterPointsConcurrent filters points inside a shape concurrently
+// Shapes with a BBox method, such as Polygon and MultiPolygon, reject points
+// outside their bounding box before the full containment test
+// points: Slice of points to filter
+// shape: Polygon, MultiPolygon or any other Container
+// Returns: Points located inside the shape, in input order
+func FilterPointsConcurrent(points []Point, shape Container) []Point {
+    contains := shape.Contains
+    if b, ok := shape.(interface{ BBox() BBox }); ok {
+        box := b.BBox()
+        contains = func(p Point) bool {
+            return box.Contains(p) && shape.Contains(p)
+        }
+    }
+
+    inside := make([]bool, len(points))
+    opts := MatrixOptions{ChunkRows: 1000}.withDefaults(len(points))
+    forEachRow(context.Background(), len(points), opts, func(i int) {
+        inside[i] = contains(points[i])
+    })
+
+    filtered := make([]Point, 0, len(points))
+    for i, ok := range inside {
+        if ok {
+            filtered = append(filtered, points[i])
+        }
+    }
+    return filtered
+}
\ No newline at end of file
@@ -0,0 +1,173 @@
+package geoutil
+
+import (
+    "math/rand"
+    "testing"
+)
+
+// Park with two lakes, the second with an island
+var (
+    park = Polygon{
+        Outer: []Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}},
+        Holes: [][]Point{
+            {{2, 2}, {2, 4}, {4, 4}, {4, 2}},
+            {{6, 6}, {8, 6}, {8, 8}, {6, 8}, {6, 6}}, // Closed and wound the other way
+        },
+    }
+    island = Polygon{Outer: []Point{{6.5, 6.5}, {6.5, 7.5}, {7.5, 7.5}, {7.5, 6.5}}}
+)
+
+func TestPolygonContainsHoles(t *testing.T) {
+    tests := []struct {
+        name string
+        p    Point
+        want bool
+    }{
+        {"inside", Point{1, 1}, true},
+        {"between holes", Point{5, 5}, true},
+        {"in first hole", Point{3, 3}, false},
+        {"in second hole", Point{7, 7}, false},
+        {"in second hole near edge", Point{6.1, 7.9}, false},
+        {"just outside hole", Point{4.1, 3}, true},
+        {"outside", Point{11, 5}, false},
+        {"outside below", Point{-1, 5}, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := park.Contains(tt.p); got != tt.want {
+                t.Errorf("Contains(%v) = %v, want %v", tt.p, got, tt.want)
+            }
+            // Winding order and closure do not matter
+            reversed := Polygon{Outer: reverseRing(park.Outer), Holes: [][]Point{reverseRing(park.Holes[0]), reverseRing(park.Holes[1])}}
+            if got := reversed.Contains(tt.p); got != tt.want {
+                t.Errorf("reversed Contains(%v) = %v, want %v", tt.p, got, tt.want)
+            }
+        })
+    }
+}
+
+// reverseRing returns the ring's vertices in the opposite order
+func reverseRing(ring []Point) []Point {
+    out := make([]Point, len(ring))
+    for i, p := range ring {
+        out[len(ring)-1-i] = p
+    }
+    return out
+}
+
+func TestMultiPolygonContains(t *testing.T) {
+    mp := MultiPolygon{park, island, {Outer: []Point{{20, 20}, {20, 22}, {22, 22}, {22, 20}}}}
+    tests := []struct {
+        name string
+        p    Point
+        want bool
+    }{
+        {"park", Point{1, 1}, true},
+        {"lake", Point{3, 3}, false},
+        {"lake around island", Point{6.2, 6.2}, false},
+        {"island in lake", Point{7, 7}, true},
+        {"second member", Point{21, 21}, true},
+        {"between members", Point{15, 15}, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := mp.Contains(tt.p); got != tt.want {
+                t.Errorf("Contains(%v) = %v, want %v", tt.p, got, tt.want)
+            }
+        })
+    }
+    if (MultiPolygon{}).Contains(Point{1, 1}) {
+        t.Error("empty multipolygon contains a point")
+    }
+    if (Polygon{Outer: []Point{{0, 0}, {1, 1}}}).Contains(Point{0.5, 0.5}) {
+        t.Error("degenerate polygon contains a point")
+    }
+}
+
+// containerFunc adapts a function to Container without a BBox method
+type containerFunc func(Point) bool
+
+func (f containerFunc) Contains(p Point) bool { return f(p) }
+
+func TestFilterPointsConcurrent(t *testing.T) {
+    rng := rand.New(rand.NewSource(15))
+    points := randomPoints(rng, Point{0, 0}, 20000, 90)
+    for i := range points {
+        points[i].Lon = angNormalize(points[i].Lon * 2)
+    }
+    // Points hugging the bulging great-circle edge south of Fiji
+    points = append(points, Point{-20.1, 180}, Point{-20.2, -179.5}, Point{-20.1, 179.9})
+
+    shapes := []struct {
+        name  string
+        shape Container
+    }{
+        {"polygon with holes", park},
+        {"multipolygon", MultiPolygon{park, island, {Outer: fijiRing, Mode: ContainmentAntimeridian}}},
+        {"antimeridian", Polygon{Outer: fijiRing, Mode: ContainmentAntimeridian}},
+        {"spherical across antimeridian", Polygon{Outer: fijiRing, Mode: ContainmentSpherical}},
+        {"arctic cap", Polygon{Outer: arcticRing, Mode: ContainmentSpherical}},
+        {"antarctic cap", Polygon{Outer: antarcticRing, Mode: ContainmentAntimeridian}},
+        {"no bounding box", containerFunc(func(p Point) bool { return p.Lat > 45 && p.Lon < 0 })},
+    }
+    for _, s := range shapes {
+        t.Run(s.name, func(t *testing.T) {
+            var want []Point
+            for _, p := range points {
+                if s.shape.Contains(p) {
+                    want = append(want, p)
+                }
+            }
+            got := FilterPointsConcurrent(points, s.shape)
+            if len(got) != len(want) {
+                t.Fatalf("got %d points, want %d", len(got), len(want))
+            }
+            for i := range got {
+                if got[i] != want[i] {
+                    t.Fatalf("point %d = %v, want %v in input order", i, got[i], want[i])
+                }
+            }
+            if len(want) == 0 {
+                t.Error("no points inside; the test does not exercise the shape")
+            }
+        })
+    }
+
+    // The prefilter must keep points on the bulge south of the vertices
+    spherical := Polygon{Outer: fijiRing, Mode: ContainmentSpherical}
+    if got := FilterPointsConcurrent([]Point{{-20.1, 180}}, spherical); len(got) != 1 {
+        t.Errorf("bounding box rejected a point on the great-circle bulge: %+v", spherical.BBox())
+    }
+    if got := FilterPointsInPolygonConcurrent([]Point{{1, 1}, {3, 3}, {11, 1}}, park.Outer); len(got) != 2 {
+        t.Errorf("FilterPointsInPolygonConcurrent kept %v, want 2 points", got)
+    }
+    if got := FilterPointsConcurrent(nil, park); len(got) != 0 {
+        t.Errorf("no points: got %v", got)
+    }
+}
+
+func TestPolygonBBoxCoversContainedPoints(t *testing.T) {
+    rng := rand.New(rand.NewSource(16))
+    shapes := []Polygon{
+        park,
+        {Outer: fijiRing, Mode: ContainmentAntimeridian},
+        {Outer: fijiRing, Mode: ContainmentSpherical},
+        {Outer: arcticRing, Mode: ContainmentSpherical},
+        {Outer: antarcticRing, Mode: ContainmentAntimeridian},
+        {Outer: starRing(Point{60, 179.5}, 50, 2), Mode: ContainmentSpherical},
+    }
+    for i, pg := range shapes {
+        box := pg.BBox()
+        for _, p := range randomPoints(rng, pg.Outer[0], 5000, 25) {
+            p = Point{Lat: clampLat(p.Lat), Lon: angNormalize(p.Lon)}
+            if pg.Contains(p) && !box.Contains(p) {
+                t.Errorf("shape %d: %v is contained but outside %+v", i, p, box)
+            }
+        }
+    }
+}
+
+// clampLat limits a latitude to [-90, 90]
+func clampLat(lat float64) float64 {
+    return max(-90, min(90, lat))
+}
@@ -0,0 +1,213 @@
+package geoutil
+
+import "math"
+
+// PreparedPolygon is a polygon or multipolygon indexed for fast repeated containment tests
+// Each ring's edges are bucketed into longitude bands, so a test only visits edges
+// crossing the query longitude instead of every vertex
+// It is immutable and safe for concurrent use, e.g. with FilterPointsConcurrent
+type PreparedPolygon struct {
+    parts []preparedPart
+    box   rect
+}
+
+type preparedPart struct {
+    outer *preparedRing
+    holes []*preparedRing
+}
+
+// preparedRing is a ring transformed to a planar frame with an edge index
+type preparedRing struct {
+    mode   ContainmentMode // Frame used: planar, unwrapped or gnomonic
+    points []Point         // Ring vertices in the planar frame
+    box    rect            // Bounds in the planar frame
+    pole   float64         // Enclosed pole for unwrapped rings, 0 if none
+    frame  gnomonicFrame   // Projection for spherical rings
+
+    // Edge index over longitude; item i is the edge from points[i-1] (wrapping) to points[i]
+    index bandIndex
+}
+
+var _ Container = (*PreparedPolygon)(nil)
+
+// NewPreparedPolygon indexes a polygon with holes
+// pg: Polygon to prepare; its Mode is honored
+// Returns: Prepared polygon
+func NewPreparedPolygon(pg Polygon) *PreparedPolygon {
+    return NewPreparedMultiPolygon(MultiPolygon{pg})
+}
+
+// NewPreparedMultiPolygon indexes every polygon of a multipolygon
+// mp: Polygons to prepare; each Mode is honored
+// Returns: Prepared polygon containing points inside any member
+func NewPreparedMultiPolygon(mp MultiPolygon) *PreparedPolygon {
+    pp := &PreparedPolygon{box: mp.bounds()}
+    for _, pg := range mp {
+        part := preparedPart{outer: prepareRing(pg.Outer, pg.Mode)}
+        for _, hole := range pg.Holes {
+            part.holes = append(part.holes, prepareRing(hole, pg.Mode))
+        }
+        pp.parts = append(pp.parts, part)
+    }
+    return pp
+}
+
+// Contains determines if a point is inside the prepared area
+// Gives the same result as Polygon.Contains or MultiPolygon.Contains
+// p: Point to check
+// Returns: true if point is inside
+func (pp *PreparedPolygon) Contains(p Point) bool {
+    if !pp.box.containsPoint(p) {
+        return false
+    }
+    for _, part := range pp.parts {
+        if !part.outer.contains(p) {
+            continue
+        }
+        inHole := false
+        for _, hole := range part.holes {
+            if hole.contains(p) {
+                inHole = true
+                break
+            }
+        }
+        if !inHole {
+            return true
+        }
+    }
+    return false
+}
+
+// prepareRing builds the planar frame and edge index for one ring
+func prepareRing(ring []Point, mode ContainmentMode) *preparedRing {
+    r := &preparedRing{mode: ContainmentPlanar, points: ring}
+    if len(ring) < 3 {
+        return r
+    }
+
+    if mode == ContainmentSpherical {
+        if f, ok := newGnomonicFrame(ring); ok {
+            r.mode = ContainmentSpherical
+            r.frame = f
+            r.points = f.ring
+        } else {
+            // Same fallback as containsSpherical
+            mode = ContainmentAntimeridian
+        }
+    }
+    if mode == ContainmentAntimeridian {
+        u := unwrapRing(ring)
+        r.mode = ContainmentAntimeridian
+        r.points = u.points
+        r.pole = u.pole
+    }
+    r.box = ringRect(r.points)
+    r.buildIndex()
+    return r
+}
+
+// buildIndex buckets edges by the longitude bands their span overlaps
+func (r *preparedRing) buildIndex() {
+    n := len(r.points)
+    r.index = newBandIndex(n, r.box.minLon, r.box.maxLon, func(i int) (float64, float64) {
+        a, b := r.points[i], r.points[(i+n-1)%n]
+        return math.Min(a.Lon, b.Lon), math.Max(a.Lon, b.Lon)
+    })
+}
+
+// bandIndex buckets intervals into equal-width bands for stabbing queries
+// Items overlapping band b are items[offsets[b]:offsets[b+1]]
+type bandIndex struct {
+    min, width float64
+    offsets    []int32
+    items      []int32
+}
+
+// newBandIndex indexes n intervals within [lo, hi] using about one band per four items
+// span: Returns the interval of item i
+func newBandIndex(n int, lo, hi float64, span func(i int) (float64, float64)) bandIndex {
+    bands := min(max(1, n/4), 1<<16)
+    ix := bandIndex{min: lo, width: (hi - lo) / float64(bands)}
+    if !(ix.width > 0) {
+        bands, ix.width = 1, 1
+    }
+
+    // Count, then fill, a compressed band -> items layout
+    ix.offsets = make([]int32, bands+1)
+    for i := 0; i < n; i++ {
+        a, b := span(i)
+        for k := ix.band(a); k <= ix.band(b); k++ {
+            ix.offsets[k+1]++
+        }
+    }
+    for k := 1; k <= bands; k++ {
+        ix.offsets[k] += ix.offsets[k-1]
+    }
+    ix.items = make([]int32, ix.offsets[bands])
+    fill := make([]int32, bands)
+    copy(fill, ix.offsets[:bands])
+    for i := 0; i < n; i++ {
+        a, b := span(i)
+        for k := ix.band(a); k <= ix.band(b); k++ {
+            ix.items[fill[k]] = int32(i)
+            fill[k]++
+        }
+    }
+    return ix
+}
+
+// band maps a coordinate to its band number
+func (ix *bandIndex) band(x float64) int {
+    b := int((x - ix.min) / ix.width)
+    return max(0, min(b, len(ix.offsets)-2))
+}
+
+// at returns the items whose interval may contain x
+func (ix *bandIndex) at(x float64) []int32 {
+    b := ix.band(x)
+    return ix.items[ix.offsets[b]:ix.offsets[b+1]]
+}
+
+// contains maps p into the ring's frame and ray-casts using the edge index
+func (r *preparedRing) contains(p Point) bool {
+    if len(r.points) < 3 {
+        return false
+    }
+    switch r.mode {
+    case ContainmentSpherical:
+        q, ok := r.frame.project(p)
+        return ok && r.containsPlanar(q)
+    case ContainmentAntimeridian:
+        if r.pole != 0 && p.Lat == r.pole {
+            return true
+        }
+        lon := p.Lon - 360*math.Ceil((p.Lon-r.box.minLon)/360)
+        for ; lon <= r.box.maxLon; lon += 360 {
+            if lon >= r.box.minLon && r.containsPlanar(Point{Lat: p.Lat, Lon: lon}) {
+                return true
+            }
+        }
+        return false
+    default:
+        return r.containsPlanar(p)
+    }
+}
+
+// containsPlanar is IsPointInPolygon restricted to the edges of p's band
+func (r *preparedRing) containsPlanar(p Point) bool {
+    if !r.box.containsPoint(p) {
+        return false
+    }
+    inside := false
+    n := len(r.points)
+    for _, e := range r.index.at(p.Lon) {
+        i := int(e)
+        j := (i + n - 1) % n
+        pi, pj := r.points[i], r.points[j]
+        if (pi.Lon > p.Lon) != (pj.Lon > p.Lon) &&
+            p.Lat < (pj.Lat-pi.Lat)*(p.Lon-pi.Lon)/(pj.Lon-pi.Lon)+pi.Lat {
+            inside = !inside
+        }
+    }
+    return inside
+}
\ No newline at end of file
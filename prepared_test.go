@@ -0,0 +1,81 @@
+package geoutil
+
+import (
+    "math"
+    "math/rand"
+    "testing"
+)
+
+// starRing builds a ring of n vertices with a wavy radius around a center
+func starRing(center Point, n int, radius float64) []Point {
+    ring := make([]Point, n)
+    for i := range ring {
+        a := 2 * math.Pi * float64(i) / float64(n)
+        r := radius * (1 + 0.1*math.Sin(37*a))
+        ring[i] = Point{Lat: center.Lat + r*math.Sin(a), Lon: center.Lon + r*math.Cos(a)}
+    }
+    return ring
+}
+
+// randomPoints returns n points in a square of half-side span around center
+func randomPoints(rng *rand.Rand, center Point, n int, span float64) []Point {
+    pts := make([]Point, n)
+    for i := range pts {
+        pts[i] = Point{
+            Lat: center.Lat + span*(2*rng.Float64()-1),
+            Lon: center.Lon + span*(2*rng.Float64()-1),
+        }
+    }
+    return pts
+}
+
+func TestPreparedPolygonMatchesPolygon(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    polygons := []struct {
+        name   string
+        pg     Polygon
+        center Point
+    }{
+        {"planar", Polygon{Outer: starRing(Point{45, 10}, 500, 2), Holes: [][]Point{starRing(Point{45, 10}, 50, 0.5)}}, Point{45, 10}},
+        {"antimeridian", Polygon{Outer: starRing(Point{-15, 180}, 500, 5), Mode: ContainmentAntimeridian}, Point{-15, 180}},
+        {"spherical", Polygon{Outer: starRing(Point{60, -30}, 500, 10), Mode: ContainmentSpherical}, Point{60, -30}},
+        {"polar cap", Polygon{Outer: arcticRing, Mode: ContainmentAntimeridian}, Point{85, 0}},
+        {"spherical polar cap", Polygon{Outer: arcticRing, Mode: ContainmentSpherical}, Point{85, 0}},
+    }
+    for _, tt := range polygons {
+        t.Run(tt.name, func(t *testing.T) {
+            pp := NewPreparedPolygon(tt.pg)
+            for _, p := range randomPoints(rng, tt.center, 5000, 12) {
+                p = Point{Lat: math.Max(-90, math.Min(90, p.Lat)), Lon: angNormalize(p.Lon)}
+                if got, want := pp.Contains(p), tt.pg.Contains(p); got != want {
+                    t.Fatalf("Contains(%v) = %v, want %v", p, got, want)
+                }
+            }
+        })
+    }
+}
+
+// BenchmarkPreparedPolygon compares a single containment test against a 50k-vertex outline
+func BenchmarkPreparedPolygon(b *testing.B) {
+    center := Point{50, 10}
+    pg := Polygon{Outer: starRing(center, 50000, 3)}
+    pts := randomPoints(rand.New(rand.NewSource(1)), center, 1<<16, 4)
+
+    b.Run("prepared", func(b *testing.B) {
+        pp := NewPreparedPolygon(pg)
+        b.ResetTimer()
+        for i := 0; i < b.N; i++ {
+            pp.Contains(pts[i&(len(pts)-1)])
+        }
+    })
+    b.Run("polygon", func(b *testing.B) {
+        for i := 0; i < b.N; i++ {
+            pg.Contains(pts[i&(len(pts)-1)])
+        }
+    })
+    b.Run("prepare", func(b *testing.B) {
+        for i := 0; i < b.N; i++ {
+            NewPreparedPolygon(pg)
+        }
+    })
+}
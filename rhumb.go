@@ -0,0 +1,112 @@
+package geoutil
+
+import "math"
+
+// RhumbDistance calculates the distance along a rhumb line (constant bearing) on EarthSphere
+// Has the distanceFunc signature accepted by BatchDistanceConcurrent
+// p1, p2: Geographic points
+// Returns: Distance in kilometers
+func RhumbDistance(p1, p2 Point) float64 {
+    return EarthSphere.RhumbDistance(p1, p2)
+}
+
+// RhumbDistance calculates the distance along a rhumb line (constant bearing)
+// p1, p2: Geographic points
+// Returns: Distance in kilometers
+func (s Sphere) RhumbDistance(p1, p2 Point) float64 {
+    φ1 := p1.Lat * degToRad
+    φ2 := p2.Lat * degToRad
+    Δφ := φ2 - φ1
+    Δλ := rhumbDeltaLon(p1, p2)
+
+    // Stretched latitude difference; q degenerates to cos φ on east-west courses
+    Δψ := mercatorDiff(φ1, φ2)
+    q := math.Cos(φ1)
+    if math.Abs(Δψ) > 1e-12 {
+        q = Δφ / Δψ
+    }
+
+    δ := math.Sqrt(Δφ*Δφ + q*q*Δλ*Δλ)
+    return δ * s.Radius
+}
+
+// RhumbBearing calculates the constant bearing of the rhumb line from p1 to p2
+// p1, p2: Geographic points
+// Returns: Bearing in degrees clockwise from north [0, 360)
+func RhumbBearing(p1, p2 Point) float64 {
+    Δψ := mercatorDiff(p1.Lat*degToRad, p2.Lat*degToRad)
+    return bearing360(math.Atan2(rhumbDeltaLon(p1, p2), Δψ) * radToDeg)
+}
+
+// RhumbDestination calculates the point reached along a rhumb line on EarthSphere
+// p: Start point
+// bearing: Constant bearing in degrees clockwise from north
+// distance: Distance in kilometers
+// Returns: Destination point
+func RhumbDestination(p Point, bearing, distance float64) Point {
+    return EarthSphere.RhumbDestination(p, bearing, distance)
+}
+
+// RhumbDestination calculates the point reached along a rhumb line
+// p: Start point
+// bearing: Constant bearing in degrees clockwise from north
+// distance: Distance in kilometers
+// Returns: Destination point
+func (s Sphere) RhumbDestination(p Point, bearing, distance float64) Point {
+    δ := distance / s.Radius
+    θ := bearing * degToRad
+    φ1 := p.Lat * degToRad
+    λ1 := p.Lon * degToRad
+
+    Δφ := δ * math.Cos(θ)
+    φ2 := φ1 + Δφ
+    // Courses passing a pole continue on the other side
+    if math.Abs(φ2) > math.Pi/2 {
+        if φ2 > 0 {
+            φ2 = math.Pi - φ2
+        } else {
+            φ2 = -math.Pi - φ2
+        }
+    }
+
+    Δψ := mercatorDiff(φ1, φ2)
+    q := math.Cos(φ1)
+    if math.Abs(Δψ) > 1e-12 {
+        q = Δφ / Δψ
+    }
+    Δλ := δ * math.Sin(θ) / q
+
+    return Point{Lat: φ2 * radToDeg, Lon: angNormalize((λ1 + Δλ) * radToDeg)}
+}
+
+// RhumbMidpoint calculates the point halfway along the rhumb line between p1 and p2
+// p1, p2: Geographic points
+// Returns: Midpoint
+func RhumbMidpoint(p1, p2 Point) Point {
+    φ1 := p1.Lat * degToRad
+    φ2 := p2.Lat * degToRad
+    λ1 := p1.Lon * degToRad
+    λ2 := λ1 + rhumbDeltaLon(p1, p2)
+
+    φ3 := (φ1 + φ2) / 2
+    f1 := math.Tan(math.Pi/4 + φ1/2)
+    f2 := math.Tan(math.Pi/4 + φ2/2)
+    f3 := math.Tan(math.Pi/4 + φ3/2)
+    λ3 := ((λ2-λ1)*math.Log(f3) + λ1*math.Log(f2) - λ2*math.Log(f1)) / math.Log(f2/f1)
+    if math.IsNaN(λ3) || math.IsInf(λ3, 0) {
+        // Parallel of latitude
+        λ3 = (λ1 + λ2) / 2
+    }
+
+    return Point{Lat: φ3 * radToDeg, Lon: angNormalize(λ3 * radToDeg)}
+}
+
+// rhumbDeltaLon returns the longitude difference in radians taking the shorter way across the antimeridian
+func rhumbDeltaLon(p1, p2 Point) float64 {
+    return angNormalize(p2.Lon-p1.Lon) * degToRad
+}
+
+// mercatorDiff returns the difference of Mercator-projected latitudes in radians
+func mercatorDiff(φ1, φ2 float64) float64 {
+    return math.Log(math.Tan(math.Pi/4+φ2/2) / math.Tan(math.Pi/4+φ1/2))
+}
\ No newline at end of file
@@ -0,0 +1,117 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// Veness's rhumb-line examples: Plymouth to Boston and Dover towards Calais
+var (
+    plymouth = Point{dms(50, 21, 59), -dms(4, 8, 2)}
+    boston   = Point{dms(42, 21, 4), -dms(71, 2, 27)}
+    dover    = Point{51.127, 1.338}
+    calais   = Point{50.964, 1.853}
+)
+
+func TestRhumbVenessExamples(t *testing.T) {
+    if got := RhumbDistance(plymouth, boston); math.Abs(got-5198) > 0.5 {
+        t.Errorf("RhumbDistance(Plymouth, Boston) = %v, want 5198", got)
+    }
+    if got, want := RhumbBearing(plymouth, boston), dms(260, 7, 38); math.Abs(got-want) > arcSecond/2 {
+        t.Errorf("RhumbBearing(Plymouth, Boston) = %v, want %v", got, want)
+    }
+    // The latitude is 46°21′31.5″, which the example rounds up
+    if got, want := RhumbMidpoint(plymouth, boston), (Point{dms(46, 21, 32), -dms(38, 49, 0)}); math.Abs(got.Lat-want.Lat) > arcSecond || math.Abs(got.Lon-want.Lon) > arcSecond/2 {
+        t.Errorf("RhumbMidpoint(Plymouth, Boston) = %v, want %v", got, want)
+    }
+    // 40.23 km is rounded to 10 m, which moves the result by up to 0.0001°
+    start := Point{dms(51, 7, 32), dms(1, 20, 17)}
+    if got, want := RhumbDestination(start, dms(116, 38, 10), 40.23), (Point{dms(50, 57, 48), dms(1, 51, 9)}); math.Abs(got.Lat-want.Lat) > arcSecond/2+1e-4 || math.Abs(got.Lon-want.Lon) > arcSecond/2+1e-4 {
+        t.Errorf("RhumbDestination = %v, want %v", got, want)
+    }
+
+    if got := RhumbDistance(dover, calais); math.Abs(got-40.31) > 0.005 {
+        t.Errorf("RhumbDistance(Dover, Calais) = %v, want 40.31", got)
+    }
+    if got := RhumbBearing(dover, calais); math.Abs(got-116.7) > 0.05 {
+        t.Errorf("RhumbBearing(Dover, Calais) = %v, want 116.7", got)
+    }
+    if got, want := RhumbMidpoint(dover, calais), (Point{51.0455, 1.5957}); math.Abs(got.Lat-want.Lat) > 5e-5 || math.Abs(got.Lon-want.Lon) > 5e-5 {
+        t.Errorf("RhumbMidpoint(Dover, Calais) = %v, want %v", got, want)
+    }
+    if got, want := RhumbDestination(dover, 116.7, 40.3), (Point{50.9642, 1.8530}); math.Abs(got.Lat-want.Lat) > 5e-5 || math.Abs(got.Lon-want.Lon) > 5e-5 {
+        t.Errorf("RhumbDestination(Dover) = %v, want %v", got, want)
+    }
+}
+
+func TestRhumbAntimeridian(t *testing.T) {
+    twoDegrees := 2 * EarthSphere.Radius * degToRad
+    tests := []struct {
+        name     string
+        p1, p2   Point
+        distance float64
+        bearing  float64
+    }{
+        {"eastward on the equator", Point{0, 179}, Point{0, -179}, twoDegrees, 90},
+        {"westward on the equator", Point{0, -179}, Point{0, 179}, twoDegrees, 270},
+        {"eastward at 60°", Point{60, 179}, Point{60, -179}, twoDegrees / 2, 90},
+        {"from 180", Point{0, 180}, Point{0, -178}, twoDegrees, 90},
+        // Same shape as 10°N 10°E to 20°N 30°E, moved across ±180°
+        {"diagonal", Point{10, 170}, Point{20, -170}, RhumbDistance(Point{10, 10}, Point{20, 30}), RhumbBearing(Point{10, 10}, Point{20, 30})},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := RhumbDistance(tt.p1, tt.p2); math.Abs(got-tt.distance) > 1e-9 {
+                t.Errorf("RhumbDistance = %v, want %v", got, tt.distance)
+            }
+            if got := RhumbBearing(tt.p1, tt.p2); math.Abs(got-tt.bearing) > 1e-9 {
+                t.Errorf("RhumbBearing = %v, want %v", got, tt.bearing)
+            }
+            if got := RhumbDestination(tt.p1, RhumbBearing(tt.p1, tt.p2), RhumbDistance(tt.p1, tt.p2)); !got.Equal(tt.p2, 1e-6) {
+                t.Errorf("RhumbDestination = %v, want %v", got, tt.p2)
+            }
+            // The midpoint lies between the ends across ±180°, not the long way round
+            mid := RhumbMidpoint(tt.p1, tt.p2)
+            if a, b := angNormalize(mid.Lon-tt.p1.Lon), angNormalize(tt.p2.Lon-tt.p1.Lon); a*b < 0 || math.Abs(a) > math.Abs(b) {
+                t.Errorf("RhumbMidpoint = %v, want a point between %v and %v", mid, tt.p1, tt.p2)
+            }
+        })
+    }
+}
+
+func TestRhumbEastWest(t *testing.T) {
+    // On a parallel the rhumb line is the parallel itself
+    p1, p2 := Point{60, 10}, Point{60, 40}
+    want := EarthSphere.Radius * math.Cos(60*degToRad) * 30 * degToRad
+    if got := RhumbDistance(p1, p2); math.Abs(got-want) > 1e-9 {
+        t.Errorf("RhumbDistance = %v, want %v", got, want)
+    }
+    if got := RhumbBearing(p1, p2); got != 90 {
+        t.Errorf("RhumbBearing = %v, want 90", got)
+    }
+    if got := RhumbMidpoint(p1, p2); !got.Equal(Point{60, 25}, 1e-6) {
+        t.Errorf("RhumbMidpoint = %v, want (60, 25)", got)
+    }
+    if got := RhumbDestination(p1, 270, want); !got.Equal(Point{60, -20}, 1e-6) {
+        t.Errorf("RhumbDestination = %v, want (60, -20)", got)
+    }
+    // Due north follows the meridian, longer than the great circle only off the meridian
+    if got, want := RhumbDistance(Point{0, 5}, Point{45, 5}), DistanceHaversine(Point{0, 5}, Point{45, 5}); math.Abs(got-want) > 1e-9 {
+        t.Errorf("meridian RhumbDistance = %v, want %v", got, want)
+    }
+    if RhumbDistance(p1, p2) <= DistanceHaversine(p1, p2) {
+        t.Error("rhumb line along a parallel is not longer than the great circle")
+    }
+}
+
+func TestRhumbDistanceAsDistanceFunc(t *testing.T) {
+    points := []Point{plymouth, boston, dover, calais, {0, 179}, {0, -179}}
+    m := BatchDistanceConcurrent(points, RhumbDistance)
+    for i := range points {
+        for j := range points {
+            if want := RhumbDistance(points[i], points[j]); math.Abs(m[i][j]-want) > 1e-9 {
+                t.Errorf("matrix[%d][%d] = %v, want %v", i, j, m[i][j], want)
+            }
+        }
+    }
+}
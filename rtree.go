@@ -0,0 +1,430 @@
+package geoutil
+
+import (
+    "context"
+    "math"
+    "sort"
+    "sync"
+)
+
+// RTree indexes polygons by bounding box and maps points to containing polygons
+// Queries may run concurrently; Insert and Delete take an exclusive lock
+type RTree struct {
+    mu         sync.RWMutex
+    root       *rtreeNode
+    items      map[int]*rtreeItem
+    maxEntries int
+    minEntries int
+}
+
+// rect is a planar latitude/longitude rectangle
+type rect struct {
+    minLat, minLon, maxLat, maxLon float64
+}
+
+type rtreeItem struct {
+    id    int
+    box   rect
+    shape Container
+}
+
+type rtreeEntry struct {
+    box   rect
+    child *rtreeNode // Set in internal nodes
+    item  *rtreeItem // Set in leaves
+}
+
+type rtreeNode struct {
+    leaf    bool
+    entries []rtreeEntry
+}
+
+// NewRTree creates an empty R-tree
+// maxEntries: Maximum entries per node (default 16, minimum 4)
+func NewRTree(maxEntries int) *RTree {
+    if maxEntries <= 0 {
+        maxEntries = 16
+    }
+    maxEntries = max(4, maxEntries)
+    return &RTree{
+        root:       &rtreeNode{leaf: true},
+        items:      make(map[int]*rtreeItem),
+        maxEntries: maxEntries,
+        minEntries: max(2, maxEntries*2/5),
+    }
+}
+
+// RTreeItem is a polygon ring with its identifier, as loaded by BulkLoadRTree
+type RTreeItem struct {
+    ID      int     // Polygon identifier
+    Polygon []Point // Polygon ring
+}
+
+// BulkLoadRTree builds an R-tree with Sort-Tile-Recursive packing
+// Much faster than repeated Insert and produces better-filled nodes
+// items: Polygons with their identifiers; later duplicates replace earlier ones
+// maxEntries: Maximum entries per node (default 16)
+func BulkLoadRTree(items []RTreeItem, maxEntries int) *RTree {
+    t := NewRTree(maxEntries)
+    for _, it := range items {
+        t.items[it.ID] = &rtreeItem{id: it.ID, box: ringRect(it.Polygon), shape: Polygon{Outer: it.Polygon}}
+    }
+    entries := make([]rtreeEntry, 0, len(t.items))
+    for _, item := range t.items {
+        entries = append(entries, rtreeEntry{box: item.box, item: item})
+    }
+    if len(entries) == 0 {
+        return t
+    }
+
+    leaf := true
+    for {
+        nodes := strPack(entries, t.maxEntries, leaf)
+        if len(nodes) == 1 {
+            t.root = nodes[0].child
+            return t
+        }
+        entries = nodes
+        leaf = false
+    }
+}
+
+// strPack groups entries into nodes by slicing on longitude then latitude
+func strPack(entries []rtreeEntry, maxEntries int, leaf bool) []rtreeEntry {
+    nodeCount := (len(entries) + maxEntries - 1) / maxEntries
+    slices := int(math.Ceil(math.Sqrt(float64(nodeCount))))
+    sliceSize := slices * maxEntries
+
+    sort.Slice(entries, func(i, j int) bool { return entries[i].box.centerLon() < entries[j].box.centerLon() })
+    nodes := make([]rtreeEntry, 0, nodeCount)
+    for s := 0; s < len(entries); s += sliceSize {
+        slice := entries[s:min(s+sliceSize, len(entries))]
+        sort.Slice(slice, func(i, j int) bool { return slice[i].box.centerLat() < slice[j].box.centerLat() })
+        for k := 0; k < len(slice); k += maxEntries {
+            node := &rtreeNode{leaf: leaf}
+            node.entries = append(node.entries, slice[k:min(k+maxEntries, len(slice))]...)
+            nodes = append(nodes, rtreeEntry{box: node.bounds(), child: node})
+        }
+    }
+    return nodes
+}
+
+// Len returns the number of indexed polygons
+func (t *RTree) Len() int {
+    t.mu.RLock()
+    defer t.mu.RUnlock()
+    return len(t.items)
+}
+
+// Insert adds a polygon, replacing any polygon with the same id
+// id: Polygon identifier
+// polygon: Polygon ring
+func (t *RTree) Insert(id int, polygon []Point) {
+    t.InsertPolygon(id, Polygon{Outer: polygon})
+}
+
+// InsertPolygon adds a polygon with holes, replacing any polygon with the same id
+// id: Polygon identifier
+// polygon: Polygon with optional holes
+func (t *RTree) InsertPolygon(id int, polygon Polygon) {
+    t.insertShape(id, polygon.bounds(), polygon)
+}
+
+// InsertMultiPolygon adds a multipolygon, replacing any polygon with the same id
+// id: Polygon identifier
+// mp: Polygons indexed as a single entry
+func (t *RTree) InsertMultiPolygon(id int, mp MultiPolygon) {
+    t.insertShape(id, mp.bounds(), mp)
+}
+
+// insertShape stores a shape under id with its bounding rectangle
+func (t *RTree) insertShape(id int, box rect, shape Container) {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    if old, ok := t.items[id]; ok {
+        t.remove(old)
+    }
+    item := &rtreeItem{id: id, box: box, shape: shape}
+    t.items[id] = item
+    t.insertItem(item)
+}
+
+// insertItem adds an item below the root, growing the tree if the root splits
+func (t *RTree) insertItem(item *rtreeItem) {
+    sibling := t.insert(t.root, rtreeEntry{box: item.box, item: item})
+    if sibling != nil {
+        t.root = &rtreeNode{entries: []rtreeEntry{
+            {box: t.root.bounds(), child: t.root},
+            {box: sibling.bounds(), child: sibling},
+        }}
+    }
+}
+
+// insert places e in the subtree of n and returns a new sibling if n was split
+func (t *RTree) insert(n *rtreeNode, e rtreeEntry) *rtreeNode {
+    if n.leaf {
+        n.entries = append(n.entries, e)
+    } else {
+        i := chooseSubtree(n, e.box)
+        sibling := t.insert(n.entries[i].child, e)
+        n.entries[i].box = n.entries[i].child.bounds()
+        if sibling != nil {
+            n.entries = append(n.entries, rtreeEntry{box: sibling.bounds(), child: sibling})
+        }
+    }
+    if len(n.entries) > t.maxEntries {
+        return t.split(n)
+    }
+    return nil
+}
+
+// chooseSubtree picks the child needing least enlargement to cover box
+func chooseSubtree(n *rtreeNode, box rect) int {
+    best := 0
+    bestEnlargement := math.Inf(1)
+    bestArea := math.Inf(1)
+    for i, e := range n.entries {
+        area := e.box.area()
+        enlargement := e.box.union(box).area() - area
+        if enlargement < bestEnlargement || (enlargement == bestEnlargement && area < bestArea) {
+            best, bestEnlargement, bestArea = i, enlargement, area
+        }
+    }
+    return best
+}
+
+// split divides an overflowing node with Guttman's quadratic algorithm
+// Returns: New sibling holding the second group
+func (t *RTree) split(n *rtreeNode) *rtreeNode {
+    entries := n.entries
+
+    // Pick the pair of seeds that would waste the most area together
+    s1, s2 := 0, 1
+    worst := math.Inf(-1)
+    for i := 0; i < len(entries); i++ {
+        for j := i + 1; j < len(entries); j++ {
+            d := entries[i].box.union(entries[j].box).area() - entries[i].box.area() - entries[j].box.area()
+            if d > worst {
+                s1, s2, worst = i, j, d
+            }
+        }
+    }
+
+    g1 := []rtreeEntry{entries[s1]}
+    g2 := []rtreeEntry{entries[s2]}
+    b1, b2 := entries[s1].box, entries[s2].box
+    rest := make([]rtreeEntry, 0, len(entries)-2)
+    for i, e := range entries {
+        if i != s1 && i != s2 {
+            rest = append(rest, e)
+        }
+    }
+
+    for len(rest) > 0 {
+        // Ensure both groups reach the minimum fill
+        if len(g1)+len(rest) == t.minEntries {
+            g1 = append(g1, rest...)
+            break
+        }
+        if len(g2)+len(rest) == t.minEntries {
+            g2 = append(g2, rest...)
+            break
+        }
+
+        // Assign the entry with the strongest preference next
+        pick, maxDiff := 0, math.Inf(-1)
+        var d1, d2 float64
+        for i, e := range rest {
+            e1 := b1.union(e.box).area() - b1.area()
+            e2 := b2.union(e.box).area() - b2.area()
+            if diff := math.Abs(e1 - e2); diff > maxDiff {
+                pick, maxDiff, d1, d2 = i, diff, e1, e2
+            }
+        }
+        e := rest[pick]
+        rest = append(rest[:pick], rest[pick+1:]...)
+
+        toFirst := d1 < d2 ||
+            (d1 == d2 && (b1.area() < b2.area() || (b1.area() == b2.area() && len(g1) <= len(g2))))
+        if toFirst {
+            g1 = append(g1, e)
+            b1 = b1.union(e.box)
+        } else {
+            g2 = append(g2, e)
+            b2 = b2.union(e.box)
+        }
+    }
+
+    n.entries = g1
+    return &rtreeNode{leaf: n.leaf, entries: g2}
+}
+
+// Delete removes the polygon with the given id
+// Returns: true if the polygon was present
+func (t *RTree) Delete(id int) bool {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    item, ok := t.items[id]
+    if !ok {
+        return false
+    }
+    t.remove(item)
+    return true
+}
+
+// remove deletes an item, condensing underfull nodes and reinserting their items
+func (t *RTree) remove(item *rtreeItem) {
+    delete(t.items, item.id)
+    var orphans []*rtreeItem
+    t.removeFrom(t.root, item, &orphans)
+
+    // Shorten the tree while the root has a single child
+    for !t.root.leaf && len(t.root.entries) == 1 {
+        t.root = t.root.entries[0].child
+    }
+    if !t.root.leaf && len(t.root.entries) == 0 {
+        t.root = &rtreeNode{leaf: true}
+    }
+    for _, o := range orphans {
+        t.insertItem(o)
+    }
+}
+
+// removeFrom deletes item from the subtree of n
+// Returns: true if the item was found
+func (t *RTree) removeFrom(n *rtreeNode, item *rtreeItem, orphans *[]*rtreeItem) bool {
+    if n.leaf {
+        for i, e := range n.entries {
+            if e.item == item {
+                n.entries = append(n.entries[:i], n.entries[i+1:]...)
+                return true
+            }
+        }
+        return false
+    }
+
+    for i, e := range n.entries {
+        if !e.box.containsRect(item.box) || !t.removeFrom(e.child, item, orphans) {
+            continue
+        }
+        if len(e.child.entries) < t.minEntries {
+            // Dissolve the underfull child and reinsert its items later
+            e.child.collect(orphans)
+            n.entries = append(n.entries[:i], n.entries[i+1:]...)
+        } else {
+            n.entries[i].box = e.child.bounds()
+        }
+        return true
+    }
+    return false
+}
+
+// collect appends all items in the subtree of n
+func (n *rtreeNode) collect(items *[]*rtreeItem) {
+    for _, e := range n.entries {
+        if n.leaf {
+            *items = append(*items, e.item)
+        } else {
+            e.child.collect(items)
+        }
+    }
+}
+
+// bounds returns the rectangle covering all entries of n
+func (n *rtreeNode) bounds() rect {
+    box := emptyRect()
+    for _, e := range n.entries {
+        box = box.union(e.box)
+    }
+    return box
+}
+
+// SearchPoint finds all polygons containing a point
+// Candidates are found by bounding box, then confirmed with an exact containment test
+// p: Query point
+// Returns: Ids of containing polygons in ascending order
+func (t *RTree) SearchPoint(p Point) []int {
+    t.mu.RLock()
+    defer t.mu.RUnlock()
+
+    var ids []int
+    t.root.searchPoint(p, &ids)
+    sort.Ints(ids)
+    return ids
+}
+
+// searchPoint descends into nodes whose rectangles contain p
+func (n *rtreeNode) searchPoint(p Point, ids *[]int) {
+    for _, e := range n.entries {
+        if !e.box.containsPoint(p) {
+            continue
+        }
+        if n.leaf {
+            if e.item.shape.Contains(p) {
+                *ids = append(*ids, e.item.id)
+            }
+        } else {
+            e.child.searchPoint(p, ids)
+        }
+    }
+}
+
+// BatchSearchPoints maps many points to their containing polygons concurrently
+// points: Query points
+// Returns: Containing polygon ids for each point in input order
+func (t *RTree) BatchSearchPoints(points []Point) [][]int {
+    results := make([][]int, len(points))
+    forEachRow(context.Background(), len(points), MatrixOptions{}.withDefaults(len(points)), func(i int) {
+        results[i] = t.SearchPoint(points[i])
+    })
+    return results
+}
+
+// emptyRect returns a rectangle that is the identity for union
+func emptyRect() rect {
+    return rect{
+        minLat: math.Inf(1), minLon: math.Inf(1),
+        maxLat: math.Inf(-1), maxLon: math.Inf(-1),
+    }
+}
+
+// ringRect returns the bounding rectangle of a ring
+func ringRect(ring []Point) rect {
+    r := emptyRect()
+    for _, p := range ring {
+        r.minLat = math.Min(r.minLat, p.Lat)
+        r.minLon = math.Min(r.minLon, p.Lon)
+        r.maxLat = math.Max(r.maxLat, p.Lat)
+        r.maxLon = math.Max(r.maxLon, p.Lon)
+    }
+    return r
+}
+
+func (r rect) union(o rect) rect {
+    return rect{
+        minLat: math.Min(r.minLat, o.minLat),
+        minLon: math.Min(r.minLon, o.minLon),
+        maxLat: math.Max(r.maxLat, o.maxLat),
+        maxLon: math.Max(r.maxLon, o.maxLon),
+    }
+}
+
+func (r rect) area() float64 {
+    if r.maxLat < r.minLat || r.maxLon < r.minLon {
+        return 0
+    }
+    return (r.maxLat - r.minLat) * (r.maxLon - r.minLon)
+}
+
+func (r rect) containsPoint(p Point) bool {
+    return p.Lat >= r.minLat && p.Lat <= r.maxLat && p.Lon >= r.minLon && p.Lon <= r.maxLon
+}
+
+func (r rect) containsRect(o rect) bool {
+    return o.minLat >= r.minLat && o.maxLat <= r.maxLat && o.minLon >= r.minLon && o.maxLon <= r.maxLon
+}
+
+func (r rect) centerLat() float64 { return (r.minLat + r.maxLat) / 2 }
+func (r rect) centerLon() float64 { return (r.minLon + r.maxLon) / 2 }
\ No newline at end of file
@@ -0,0 +1,237 @@
+package geoutil
+
+import (
+    "math/rand"
+    "slices"
+    "sync"
+    "sync/atomic"
+    "testing"
+)
+
+// randomRings returns n small star-shaped rings scattered over a region
+func randomRings(rng *rand.Rand, n int) []RTreeItem {
+    items := make([]RTreeItem, n)
+    for i := range items {
+        center := Point{Lat: 40 + 10*rng.Float64(), Lon: -10 + 20*rng.Float64()}
+        items[i] = RTreeItem{ID: i, Polygon: starRing(center, 8+rng.Intn(30), 0.1+0.5*rng.Float64())}
+    }
+    return items
+}
+
+// bruteContaining returns the ids of rings containing p in ascending order
+func bruteContaining(rings map[int][]Point, p Point) []int {
+    var ids []int
+    for id, ring := range rings {
+        if IsPointInPolygon(p, ring) {
+            ids = append(ids, id)
+        }
+    }
+    slices.Sort(ids)
+    return ids
+}
+
+// checkRTree verifies node boxes, fill and depth, and that the tree holds exactly the item map
+func checkRTree(t *testing.T, tr *RTree) {
+    t.Helper()
+    leafDepth := -1
+    seen := map[int]bool{}
+    var walk func(n *rtreeNode, box rect, depth int, root bool)
+    walk = func(n *rtreeNode, box rect, depth int, root bool) {
+        if !root && (len(n.entries) < tr.minEntries || len(n.entries) > tr.maxEntries) {
+            t.Errorf("node at depth %d has %d entries, want %d to %d", depth, len(n.entries), tr.minEntries, tr.maxEntries)
+        }
+        if !root && n.bounds() != box {
+            t.Errorf("node at depth %d: parent box %+v, want %+v", depth, box, n.bounds())
+        }
+        for _, e := range n.entries {
+            if n.leaf {
+                if seen[e.item.id] || tr.items[e.item.id] != e.item {
+                    t.Errorf("leaf entry %d duplicated or not in the item map", e.item.id)
+                }
+                seen[e.item.id] = true
+                if e.box != e.item.box {
+                    t.Errorf("leaf entry %d box %+v, want %+v", e.item.id, e.box, e.item.box)
+                }
+                continue
+            }
+            walk(e.child, e.box, depth+1, false)
+        }
+        if n.leaf {
+            if leafDepth >= 0 && depth != leafDepth {
+                t.Errorf("leaves at depths %d and %d", leafDepth, depth)
+            }
+            leafDepth = depth
+        }
+    }
+    walk(tr.root, rect{}, 0, true)
+    if len(seen) != len(tr.items) || tr.Len() != len(tr.items) {
+        t.Errorf("tree holds %d items, item map %d", len(seen), len(tr.items))
+    }
+}
+
+func TestRTreeMatchesBruteForce(t *testing.T) {
+    rng := rand.New(rand.NewSource(12))
+    tr := NewRTree(6)
+    rings := map[int][]Point{}
+    queries := randomPoints(rng, Point{45, 0}, 3000, 6)
+    check := func(stage string) {
+        t.Helper()
+        checkRTree(t, tr)
+        for _, q := range queries {
+            if got, want := tr.SearchPoint(q), bruteContaining(rings, q); !slices.Equal(got, want) {
+                t.Fatalf("%s: SearchPoint(%v) = %v, want %v", stage, q, got, want)
+            }
+        }
+    }
+
+    for _, it := range randomRings(rng, 400) {
+        tr.Insert(it.ID, it.Polygon)
+        rings[it.ID] = it.Polygon
+    }
+    check("after inserts")
+
+    for _, id := range rng.Perm(400)[:250] {
+        if !tr.Delete(id) {
+            t.Fatalf("Delete(%d) = false", id)
+        }
+        delete(rings, id)
+    }
+    if tr.Delete(1000) {
+        t.Error("Delete of a missing id = true")
+    }
+    check("after deletes")
+
+    // Re-inserting an existing id replaces its polygon
+    for i, it := range randomRings(rng, 100) {
+        id := i * 4
+        tr.Insert(id, it.Polygon)
+        rings[id] = it.Polygon
+    }
+    check("after replacements")
+
+    for id := range rings {
+        tr.Delete(id)
+    }
+    rings = map[int][]Point{}
+    check("after deleting everything")
+}
+
+func TestBulkLoadRTreeMatchesInsert(t *testing.T) {
+    rng := rand.New(rand.NewSource(13))
+    items := randomRings(rng, 1000)
+    // Later duplicates replace earlier ones
+    items = append(items, RTreeItem{ID: 7, Polygon: starRing(Point{45, 0}, 12, 0.3)})
+
+    bulk := BulkLoadRTree(items, 8)
+    incremental := NewRTree(8)
+    rings := map[int][]Point{}
+    for _, it := range items {
+        incremental.Insert(it.ID, it.Polygon)
+        rings[it.ID] = it.Polygon
+    }
+    if bulk.Len() != 1000 || incremental.Len() != 1000 {
+        t.Fatalf("Len = %d bulk, %d incremental, want 1000", bulk.Len(), incremental.Len())
+    }
+    walkLeaves := func(n *rtreeNode) int {
+        depth := 0
+        for !n.leaf {
+            n = n.entries[0].child
+            depth++
+        }
+        return depth
+    }
+    if d := walkLeaves(bulk.root); d > walkLeaves(incremental.root) {
+        t.Errorf("bulk-loaded tree is deeper (%d) than the incremental one", d)
+    }
+
+    queries := randomPoints(rng, Point{45, 0}, 3000, 6)
+    for _, q := range queries {
+        got, want := bulk.SearchPoint(q), incremental.SearchPoint(q)
+        if !slices.Equal(got, want) || !slices.Equal(got, bruteContaining(rings, q)) {
+            t.Fatalf("SearchPoint(%v) = %v bulk, %v incremental", q, got, want)
+        }
+    }
+
+    // A bulk-loaded tree accepts further updates
+    for id := 0; id < 500; id++ {
+        bulk.Delete(id)
+        delete(rings, id)
+    }
+    bulk.Insert(5000, starRing(Point{45, 0}, 10, 1))
+    rings[5000] = starRing(Point{45, 0}, 10, 1)
+    for _, q := range queries[:500] {
+        if got, want := bulk.SearchPoint(q), bruteContaining(rings, q); !slices.Equal(got, want) {
+            t.Fatalf("after updates: SearchPoint(%v) = %v, want %v", q, got, want)
+        }
+    }
+
+    if empty := BulkLoadRTree(nil, 0); empty.Len() != 0 || empty.SearchPoint(Point{45, 0}) != nil {
+        t.Error("empty bulk load is not empty")
+    }
+}
+
+func TestRTreeConcurrentSearch(t *testing.T) {
+    rng := rand.New(rand.NewSource(14))
+    // Polygons 0..99 stay put while a writer churns ids 100 and up
+    stable := randomRings(rng, 100)
+    churn := randomRings(rng, 200)
+    tr := BulkLoadRTree(stable, 8)
+    stableRings := map[int][]Point{}
+    for _, it := range stable {
+        stableRings[it.ID] = it.Polygon
+    }
+    queries := randomPoints(rng, Point{45, 0}, 500, 6)
+    want := make([][]int, len(queries))
+    for i, q := range queries {
+        want[i] = bruteContaining(stableRings, q)
+    }
+
+    var done atomic.Bool
+    var wg sync.WaitGroup
+    wg.Add(1)
+    go func() {
+        defer wg.Done()
+        defer done.Store(true)
+        for round := 0; round < 20; round++ {
+            for _, it := range churn {
+                tr.Insert(100+it.ID, it.Polygon)
+            }
+            for _, it := range churn {
+                tr.Delete(100 + it.ID)
+            }
+        }
+    }()
+
+    for r := 0; r < 4; r++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for !done.Load() {
+                for i, q := range queries {
+                    var got []int
+                    for _, id := range tr.SearchPoint(q) {
+                        if id < 100 {
+                            got = append(got, id)
+                        }
+                    }
+                    if !slices.Equal(got, want[i]) {
+                        t.Errorf("SearchPoint(%v) stable ids = %v, want %v", q, got, want[i])
+                        return
+                    }
+                }
+            }
+        }()
+    }
+    wg.Wait()
+
+    checkRTree(t, tr)
+    if tr.Len() != len(stable) {
+        t.Errorf("Len = %d after churn, want %d", tr.Len(), len(stable))
+    }
+    batch := tr.BatchSearchPoints(queries)
+    for i := range queries {
+        if !slices.Equal(batch[i], want[i]) {
+            t.Errorf("BatchSearchPoints[%d] = %v, want %v", i, batch[i], want[i])
+        }
+    }
+}
@@ -0,0 +1,557 @@
+package geoutil
+
+import (
+    "container/heap"
+    "context"
+    "math"
+)
+
+// SimplifyMethod selects the vertex reduction algorithm
+type SimplifyMethod int
+
+const (
+    // DouglasPeucker keeps vertices farther than the tolerance from the simplified line (default)
+    DouglasPeucker SimplifyMethod = iota
+    // VisvalingamWhyatt drops vertices whose triangle with their neighbors has an
+    // area below tolerance² square meters, smallest first
+    VisvalingamWhyatt
+)
+
+// SimplifyOptions configures line and ring simplification
+type SimplifyOptions struct {
+    Method           SimplifyMethod // Algorithm (default DouglasPeucker)
+    PreserveTopology bool           // Keep extra vertices so simplified segments do not cross or pass over other vertices
+}
+
+// SimplifyPolyline reduces the number of vertices of a polyline
+// The first and last points are always kept
+// line: Polyline vertices
+// tolerance: Maximum deviation in meters
+// opts: Algorithm and topology options
+// Returns: Simplified copy of the line
+func SimplifyPolyline(line []Point, tolerance float64, opts SimplifyOptions) []Point {
+    return simplifyRings([][]Point{line}, false, tolerance, opts)[0]
+}
+
+// SimplifyRing reduces the number of vertices of a polygon ring
+// At least 3 vertices are kept and a closed ring stays closed
+// ring: Ring vertices, open or closed
+// tolerance: Maximum deviation in meters
+// opts: Algorithm and topology options
+// Returns: Simplified copy of the ring
+func SimplifyRing(ring []Point, tolerance float64, opts SimplifyOptions) []Point {
+    return simplifyRings([][]Point{ring}, true, tolerance, opts)[0]
+}
+
+// Simplify reduces the number of vertices of the outer ring and holes
+// With PreserveTopology, rings are also kept from crossing each other
+// tolerance: Maximum deviation in meters
+// opts: Algorithm and topology options
+// Returns: Simplified copy of the polygon
+func (pg Polygon) Simplify(tolerance float64, opts SimplifyOptions) Polygon {
+    rings := simplifyRings(append([][]Point{pg.Outer}, pg.Holes...), true, tolerance, opts)
+    out := Polygon{Outer: rings[0], Mode: pg.Mode}
+    if len(pg.Holes) > 0 {
+        out.Holes = rings[1:]
+    }
+    return out
+}
+
+// Simplify reduces the number of vertices of every polygon
+// tolerance: Maximum deviation in meters
+// opts: Algorithm and topology options
+// Returns: Simplified copy of the multipolygon
+func (mp MultiPolygon) Simplify(tolerance float64, opts SimplifyOptions) MultiPolygon {
+    out := make(MultiPolygon, len(mp))
+    for i, pg := range mp {
+        out[i] = pg.Simplify(tolerance, opts)
+    }
+    return out
+}
+
+// BatchSimplify simplifies many polylines concurrently
+// lines: Polylines, e.g. GPS tracks
+// tolerance: Maximum deviation in meters
+// opts: Algorithm and topology options
+// Returns: Simplified lines in input order
+func BatchSimplify(lines [][]Point, tolerance float64, opts SimplifyOptions) [][]Point {
+    results := make([][]Point, len(lines))
+    forEachRow(context.Background(), len(lines), MatrixOptions{}.withDefaults(len(lines)), func(i int) {
+        results[i] = SimplifyPolyline(lines[i], tolerance, opts)
+    })
+    return results
+}
+
+// simplifyShape is a line or ring prepared for simplification
+// Rings repeat their first vertex at the end so both kinds are open sequences
+type simplifyShape struct {
+    pts  []Point
+    vecs []vec3
+    xy   []Point // Continuous-longitude coordinates for intersection tests
+    keep []bool
+    ring bool
+}
+
+// simplifyRings simplifies lines or rings that share a topology check
+func simplifyRings(rings [][]Point, ring bool, tolerance float64, opts SimplifyOptions) [][]Point {
+    // Angular tolerance on the unit sphere
+    tol := tolerance / (EarthSphere.Radius * 1000)
+    shapes := make([]*simplifyShape, len(rings))
+    for k, r := range rings {
+        shapes[k] = newSimplifyShape(r, ring)
+    }
+    if len(shapes) > 1 {
+        // Put every shape next to the first one across the antimeridian
+        ref := shapes[0].xy
+        for _, s := range shapes[1:] {
+            if len(ref) == 0 || len(s.xy) == 0 {
+                continue
+            }
+            shift := 360 * math.Round((ref[0].Lon-s.xy[0].Lon)/360)
+            for i := range s.xy {
+                s.xy[i].Lon += shift
+            }
+        }
+    }
+
+    switch opts.Method {
+    case VisvalingamWhyatt:
+        // Start from every vertex and remove the insignificant ones
+        for _, s := range shapes {
+            for i := range s.keep {
+                s.keep[i] = true
+            }
+        }
+        var grid *segmentGrid
+        if opts.PreserveTopology {
+            grid = newSegmentGrid(shapes)
+        }
+        for k, s := range shapes {
+            s.visvalingam(k, tol*tol, grid)
+        }
+    default:
+        for _, s := range shapes {
+            s.douglasPeucker(tol)
+        }
+        if opts.PreserveTopology {
+            refineCrossings(shapes)
+        }
+    }
+
+    out := make([][]Point, len(shapes))
+    for k, s := range shapes {
+        out[k] = s.result(rings[k])
+    }
+    return out
+}
+
+// newSimplifyShape copies the vertices and marks the fixed endpoints
+func newSimplifyShape(r []Point, ring bool) *simplifyShape {
+    s := &simplifyShape{ring: ring}
+    s.pts = r
+    if ring && len(r) > 0 {
+        s.pts = append(append([]Point(nil), openRing(r)...), r[0])
+    }
+    n := len(s.pts)
+    s.vecs = make([]vec3, n)
+    s.xy = make([]Point, n)
+    s.keep = make([]bool, n)
+    for i, p := range s.pts {
+        s.vecs[i] = toVec3(p)
+        s.xy[i] = p
+        if i > 0 {
+            s.xy[i].Lon = s.xy[i-1].Lon + angNormalize(p.Lon-s.pts[i-1].Lon)
+        }
+    }
+    if n > 0 {
+        s.keep[0], s.keep[n-1] = true, true
+    }
+    // Rings too small to simplify are kept whole
+    if (ring && n <= 4) || n <= 2 {
+        for i := range s.keep {
+            s.keep[i] = true
+        }
+    }
+    return s
+}
+
+// result collects the kept vertices, restoring the input's ring closure
+func (s *simplifyShape) result(orig []Point) []Point {
+    out := make([]Point, 0, len(s.pts))
+    for i, p := range s.pts {
+        if s.keep[i] {
+            out = append(out, p)
+        }
+    }
+    if s.ring && len(out) > 0 && (len(orig) < 2 || orig[0] != orig[len(orig)-1]) {
+        // Input ring was open
+        out = out[:len(out)-1]
+    }
+    return out
+}
+
+// douglasPeucker keeps vertices that deviate more than tol radians from the simplified line
+func (s *simplifyShape) douglasPeucker(tol float64) {
+    n := len(s.pts)
+    if n <= 2 {
+        return
+    }
+    type span struct{ i, j int }
+    var stack []span
+    if s.ring {
+        // Split the ring at the vertex farthest from its start so it has three fixed vertices
+        far, best := 0, -1.0
+        for i := 1; i < n-1; i++ {
+            if d := angleBetween(s.vecs[0], s.vecs[i]); d > best {
+                far, best = i, d
+            }
+        }
+        s.keep[far] = true
+        stack = append(stack, span{0, far}, span{far, n - 1})
+        // A ring needs a third vertex off the 0-far chord
+        third, best := 0, -1.0
+        for i := 1; i < n-1; i++ {
+            if i == far {
+                continue
+            }
+            if d := arcDistance(s.vecs[i], s.vecs[0], s.vecs[far]); d > best {
+                third, best = i, d
+            }
+        }
+        if third > 0 {
+            s.keep[third] = true
+            if third < far {
+                stack = []span{{0, third}, {third, far}, {far, n - 1}}
+            } else {
+                stack = []span{{0, far}, {far, third}, {third, n - 1}}
+            }
+        }
+    } else {
+        stack = append(stack, span{0, n - 1})
+    }
+
+    for len(stack) > 0 {
+        sp := stack[len(stack)-1]
+        stack = stack[:len(stack)-1]
+        idx, d := s.farthest(sp.i, sp.j)
+        if idx > 0 && d > tol {
+            s.keep[idx] = true
+            stack = append(stack, span{sp.i, idx}, span{idx, sp.j})
+        }
+    }
+}
+
+// farthest returns the vertex strictly between i and j farthest from the arc i-j
+func (s *simplifyShape) farthest(i, j int) (int, float64) {
+    idx, best := -1, -1.0
+    for k := i + 1; k < j; k++ {
+        if d := arcDistance(s.vecs[k], s.vecs[i], s.vecs[j]); d > best {
+            idx, best = k, d
+        }
+    }
+    return idx, best
+}
+
+// vwVertex is a removal candidate ordered by effective area
+type vwVertex struct {
+    index      int
+    area       float64
+    prev, next int
+    pos        int // Position in the heap, -1 once removed
+}
+
+type vwHeap []*vwVertex
+
+func (h vwHeap) Len() int           { return len(h) }
+func (h vwHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+func (h vwHeap) Swap(i, j int) {
+    h[i], h[j] = h[j], h[i]
+    h[i].pos = i
+    h[j].pos = j
+}
+func (h *vwHeap) Push(x any) {
+    v := x.(*vwVertex)
+    v.pos = len(*h)
+    *h = append(*h, v)
+}
+func (h *vwHeap) Pop() any {
+    old := *h
+    v := old[len(old)-1]
+    v.pos = -1
+    *h = old[:len(old)-1]
+    return v
+}
+
+// visvalingam removes vertices in order of increasing triangle area below minArea (steradians)
+// grid: Segment index for topology checks, or nil
+func (s *simplifyShape) visvalingam(shape int, minArea float64, grid *segmentGrid) {
+    n := len(s.pts)
+    if n <= 2 || (s.ring && n <= 4) {
+        return
+    }
+    minKept := 2
+    if s.ring {
+        // Three distinct vertices plus the repeated start
+        minKept = 4
+    }
+
+    verts := make([]*vwVertex, n)
+    h := make(vwHeap, 0, n-2)
+    for i := 1; i < n-1; i++ {
+        verts[i] = &vwVertex{index: i, prev: i - 1, next: i + 1}
+        verts[i].area = triangleArea(s.vecs[i-1], s.vecs[i], s.vecs[i+1])
+        heap.Push(&h, verts[i])
+    }
+
+    kept := n
+    maxArea := 0.0
+    for h.Len() > 0 && kept > minKept {
+        v := heap.Pop(&h).(*vwVertex)
+        if v.area >= minArea {
+            break
+        }
+        if grid != nil && (grid.crosses(s, shape, v.prev, v.next) ||
+            grid.encloses(s, shape, v.prev, v.next, []Point{s.xy[v.prev], s.xy[v.index], s.xy[v.next]})) {
+            // Removing v would make the outline cross itself or move past another ring; keep it
+            continue
+        }
+        // Effective areas never decrease, so earlier removals bound later ones
+        maxArea = math.Max(maxArea, v.area)
+        s.keep[v.index] = false
+        kept--
+        if grid != nil {
+            grid.remove(shape, v.prev, v.index, s.xy)
+            grid.remove(shape, v.index, v.next, s.xy)
+            grid.add(shape, v.prev, v.next, s.xy)
+        }
+
+        for _, k := range []int{v.prev, v.next} {
+            u := verts[k]
+            if u == nil || u.pos < 0 {
+                continue
+            }
+            if k == v.prev {
+                u.next = v.next
+            } else {
+                u.prev = v.prev
+            }
+            u.area = math.Max(maxArea, triangleArea(s.vecs[u.prev], s.vecs[u.index], s.vecs[u.next]))
+            heap.Fix(&h, u.pos)
+        }
+    }
+}
+
+// refineCrossings adds back the farthest dropped vertex of any simplified segment
+// crossing another or passing over another vertex until neither happens
+func refineCrossings(shapes []*simplifyShape) {
+    for changed := true; changed; {
+        changed = false
+        grid := newSegmentGrid(shapes)
+        for k, s := range shapes {
+            i := 0
+            for j := 1; j < len(s.pts); j++ {
+                if !s.keep[j] {
+                    continue
+                }
+                if j > i+1 && (grid.crosses(s, k, i, j) || grid.encloses(s, k, i, j, s.xy[i:j+1])) {
+                    idx, _ := s.farthest(i, j)
+                    s.keep[idx] = true
+                    changed = true
+                }
+                i = j
+            }
+        }
+    }
+}
+
+// segmentRef identifies the segment between vertices i and j of a shape
+type segmentRef struct {
+    shape, i, j int
+}
+
+// segmentGrid buckets segments into uniform cells for intersection queries
+type segmentGrid struct {
+    shapes     []*simplifyShape
+    minX, minY float64
+    cell       float64
+    cells      map[[2]int][]segmentRef
+}
+
+// newSegmentGrid indexes the current (kept) segments of all shapes
+func newSegmentGrid(shapes []*simplifyShape) *segmentGrid {
+    box := emptyRect()
+    count := 0
+    for _, s := range shapes {
+        for _, p := range s.xy {
+            box = box.union(rect{p.Lat, p.Lon, p.Lat, p.Lon})
+        }
+        count += len(s.xy)
+    }
+    g := &segmentGrid{shapes: shapes, minX: box.minLon, minY: box.minLat, cells: make(map[[2]int][]segmentRef)}
+    g.cell = math.Max(box.maxLon-box.minLon, box.maxLat-box.minLat) / math.Max(1, math.Sqrt(float64(count)))
+    if !(g.cell > 0) {
+        g.cell = 1
+    }
+
+    for k, s := range shapes {
+        i := -1
+        for j := range s.pts {
+            if !s.keep[j] {
+                continue
+            }
+            if i >= 0 {
+                g.add(k, i, j, s.xy)
+            }
+            i = j
+        }
+    }
+    return g
+}
+
+// span returns the cell range covered by the segment's bounding box
+func (g *segmentGrid) span(a, b Point) (x0, y0, x1, y1 int) {
+    x0 = int(math.Floor((math.Min(a.Lon, b.Lon) - g.minX) / g.cell))
+    x1 = int(math.Floor((math.Max(a.Lon, b.Lon) - g.minX) / g.cell))
+    y0 = int(math.Floor((math.Min(a.Lat, b.Lat) - g.minY) / g.cell))
+    y1 = int(math.Floor((math.Max(a.Lat, b.Lat) - g.minY) / g.cell))
+    return
+}
+
+func (g *segmentGrid) add(shape, i, j int, xy []Point) {
+    x0, y0, x1, y1 := g.span(xy[i], xy[j])
+    for x := x0; x <= x1; x++ {
+        for y := y0; y <= y1; y++ {
+            key := [2]int{x, y}
+            g.cells[key] = append(g.cells[key], segmentRef{shape, i, j})
+        }
+    }
+}
+
+func (g *segmentGrid) remove(shape, i, j int, xy []Point) {
+    x0, y0, x1, y1 := g.span(xy[i], xy[j])
+    for x := x0; x <= x1; x++ {
+        for y := y0; y <= y1; y++ {
+            key := [2]int{x, y}
+            refs := g.cells[key]
+            for k, r := range refs {
+                if r == (segmentRef{shape, i, j}) {
+                    refs[k] = refs[len(refs)-1]
+                    g.cells[key] = refs[:len(refs)-1]
+                    break
+                }
+            }
+        }
+    }
+}
+
+// crosses reports whether segment i-j of s intersects an indexed segment
+// that does not share one of its vertices
+func (g *segmentGrid) crosses(s *simplifyShape, shape, i, j int) bool {
+    a, b := s.xy[i], s.xy[j]
+    x0, y0, x1, y1 := g.span(a, b)
+    for x := x0; x <= x1; x++ {
+        for y := y0; y <= y1; y++ {
+            for _, r := range g.cells[[2]int{x, y}] {
+                if r.shape == shape && s.sharesVertex(r.i, r.j, i, j) {
+                    continue
+                }
+                o := g.shapes[r.shape]
+                if segmentsIntersect(a, b, o.xy[r.i], o.xy[r.j]) {
+                    return true
+                }
+            }
+        }
+    }
+    return false
+}
+
+// encloses reports whether an indexed vertex other than vertices i to j of s lies
+// inside region, the area between a simplified segment and the vertices it replaces
+// Without crossings, such a vertex is one that the segment would move past,
+// like a hole left outside when a bump in its outer ring is removed
+func (g *segmentGrid) encloses(s *simplifyShape, shape, i, j int, region []Point) bool {
+    box := emptyRect()
+    for _, p := range region {
+        box = box.union(rect{p.Lat, p.Lon, p.Lat, p.Lon})
+    }
+    last := len(s.pts) - 1
+    inRange := func(k int) bool {
+        return k >= i && k <= j || s.ring && (k == 0 && j == last || k == last && i == 0)
+    }
+    x0, y0, x1, y1 := g.span(Point{box.minLat, box.minLon}, Point{box.maxLat, box.maxLon})
+    for x := x0; x <= x1; x++ {
+        for y := y0; y <= y1; y++ {
+            for _, r := range g.cells[[2]int{x, y}] {
+                o := g.shapes[r.shape]
+                for _, k := range []int{r.i, r.j} {
+                    if r.shape == shape && inRange(k) {
+                        continue
+                    }
+                    if p := o.xy[k]; box.containsPoint(p) && IsPointInPolygon(p, region) {
+                        return true
+                    }
+                }
+            }
+        }
+    }
+    return false
+}
+
+// sharesVertex reports whether segments i1-j1 and i2-j2 have a common vertex
+// The last vertex of a ring is the same as the first
+func (s *simplifyShape) sharesVertex(i1, j1, i2, j2 int) bool {
+    norm := func(i int) int {
+        if s.ring && i == len(s.pts)-1 {
+            return 0
+        }
+        return i
+    }
+    i1, j1, i2, j2 = norm(i1), norm(j1), norm(i2), norm(j2)
+    return i1 == i2 || i1 == j2 || j1 == i2 || j1 == j2
+}
+
+// segmentsIntersect tests planar segments a-b and c-d, including touching
+func segmentsIntersect(a, b, c, d Point) bool {
+    d1 := orient(c, d, a)
+    d2 := orient(c, d, b)
+    d3 := orient(a, b, c)
+    d4 := orient(a, b, d)
+    if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+        return true
+    }
+    return (d1 == 0 && onSegment(c, d, a)) || (d2 == 0 && onSegment(c, d, b)) ||
+        (d3 == 0 && onSegment(a, b, c)) || (d4 == 0 && onSegment(a, b, d))
+}
+
+// orient returns twice the signed area of triangle a, b, c in lon/lat coordinates
+func orient(a, b, c Point) float64 {
+    return (b.Lon-a.Lon)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lon-a.Lon)
+}
+
+// onSegment reports whether p, collinear with a-b, lies within its bounding box
+func onSegment(a, b, p Point) bool {
+    return p.Lon >= math.Min(a.Lon, b.Lon) && p.Lon <= math.Max(a.Lon, b.Lon) &&
+        p.Lat >= math.Min(a.Lat, b.Lat) && p.Lat <= math.Max(a.Lat, b.Lat)
+}
+
+// angleBetween returns the angle in radians between unit vectors
+func angleBetween(a, b vec3) float64 {
+    return math.Atan2(a.cross(b).norm(), a.dot(b))
+}
+
+// arcDistance returns the angular distance from p to the shorter great-circle arc a-b
+func arcDistance(p, a, b vec3) float64 {
+    n := a.cross(b)
+    if nn := n.norm(); nn > 1e-15 {
+        n = n.scale(1 / nn)
+        if a.cross(p).dot(n) >= 0 && p.cross(b).dot(n) >= 0 {
+            return math.Abs(math.Asin(clampUnit(p.dot(n))))
+        }
+    }
+    return math.Min(angleBetween(p, a), angleBetween(p, b))
+}
+
+// triangleArea returns the spherical excess of triangle a, b, c in steradians
+func triangleArea(a, b, c vec3) float64 {
+    return 2 * math.Atan2(math.Abs(a.dot(b.cross(c))), 1+a.dot(b)+b.dot(c)+c.dot(a))
+}
\ No newline at end of file
@@ -0,0 +1,268 @@
+package geoutil
+
+import (
+    "math"
+    "math/rand"
+    "testing"
+)
+
+// metersToDegrees converts a distance along a meridian on EarthSphere to degrees
+func metersToDegrees(m float64) float64 {
+    return m / (EarthSphere.Radius * 1000) * radToDeg
+}
+
+// selfIntersects reports whether non-adjacent segments of the lines cross or touch
+// Rings are given closed, so their last segment is adjacent to the first
+func selfIntersects(lines ...[]Point) bool {
+    type seg struct {
+        a, b    Point
+        line, i int
+    }
+    var segs []seg
+    for k, l := range lines {
+        for i := 0; i+1 < len(l); i++ {
+            segs = append(segs, seg{l[i], l[i+1], k, i})
+        }
+    }
+    for x := range segs {
+        for y := x + 1; y < len(segs); y++ {
+            s, u := segs[x], segs[y]
+            if s.line == u.line {
+                n := len(lines[s.line]) - 1
+                closed := lines[s.line][0] == lines[s.line][n]
+                if u.i == s.i+1 || (closed && s.i == 0 && u.i == n-1) {
+                    continue
+                }
+            }
+            if segmentsIntersect(s.a, s.b, u.a, u.b) {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// slicesContain reports whether p is one of the points
+func slicesContain(points []Point, p Point) bool {
+    for _, q := range points {
+        if q == p {
+            return true
+        }
+    }
+    return false
+}
+
+// spiral returns a polyline winding outward around a center with turns about
+// gap degrees apart, plus a little noise
+func spiral(rng *rand.Rand, center Point, turns, perTurn int, gap float64) []Point {
+    var line []Point
+    for i := 0; i < turns*perTurn; i++ {
+        θ := 2 * math.Pi * float64(i) / float64(perTurn)
+        r := gap * (1 + θ/(2*math.Pi))
+        r += gap * 0.05 * (rng.Float64() - 0.5)
+        line = append(line, Point{Lat: center.Lat + r*math.Sin(θ), Lon: center.Lon + r*math.Cos(θ)})
+    }
+    return line
+}
+
+func TestSimplifyToleranceInMeters(t *testing.T) {
+    // A 50 m bump in the middle of a one-degree segment along the equator
+    bump := []Point{{0, 0}, {metersToDegrees(50), 0.5}, {0, 1}}
+    tests := []struct {
+        method    SimplifyMethod
+        tolerance float64
+        want      int
+    }{
+        {DouglasPeucker, 48, 3},
+        {DouglasPeucker, 52, 2},
+        // The triangle covers 0.5 × 111.2 km × 50 m ≈ 1667² m²
+        {VisvalingamWhyatt, 1600, 3},
+        {VisvalingamWhyatt, 1750, 2},
+    }
+    for _, tt := range tests {
+        if got := SimplifyPolyline(bump, tt.tolerance, SimplifyOptions{Method: tt.method}); len(got) != tt.want {
+            t.Errorf("method %d, tolerance %v m: got %d points, want %d", tt.method, tt.tolerance, len(got), tt.want)
+        }
+    }
+}
+
+func TestSimplifyDouglasPeuckerDeviation(t *testing.T) {
+    rng := rand.New(rand.NewSource(8))
+    // A GPS-like track with points every few hundred meters
+    track := []Point{{52.52, 13.40}}
+    bearing := 0.0
+    for range 2000 {
+        bearing += 30 * (rng.Float64() - 0.5)
+        track = append(track, Destination(track[len(track)-1], bearing, 0.1+0.3*rng.Float64()))
+    }
+
+    for _, tolerance := range []float64{5, 50, 500} {
+        got := SimplifyPolyline(track, tolerance, SimplifyOptions{})
+        if got[0] != track[0] || got[len(got)-1] != track[len(track)-1] {
+            t.Fatalf("tolerance %v: endpoints not kept", tolerance)
+        }
+        if len(got) >= len(track) {
+            t.Errorf("tolerance %v: nothing removed", tolerance)
+        }
+        // Every dropped point stays within the tolerance of the segment replacing it
+        seg := 0
+        for _, p := range track {
+            if seg+1 < len(got) && p == got[seg+1] {
+                seg++
+                continue
+            }
+            if seg+1 == len(got) {
+                t.Fatalf("tolerance %v: result is not a subsequence of the input", tolerance)
+            }
+            if _, d := ClosestPointOnSegment(p, got[seg], got[seg+1]); d*1000 > tolerance*1.0001 {
+                t.Fatalf("tolerance %v: %v is %v m from its simplified segment", tolerance, p, d*1000)
+            }
+        }
+    }
+}
+
+func TestSimplifyKeepsEndpoints(t *testing.T) {
+    rng := rand.New(rand.NewSource(9))
+    // A zigzag heading east never crosses itself, so topology allows dropping every vertex
+    line := make([]Point, 100)
+    for i := range line {
+        line[i] = Point{Lat: 10 + rng.Float64(), Lon: 20 + 0.01*float64(i)}
+    }
+    for _, method := range []SimplifyMethod{DouglasPeucker, VisvalingamWhyatt} {
+        for _, topo := range []bool{false, true} {
+            opts := SimplifyOptions{Method: method, PreserveTopology: topo}
+            got := SimplifyPolyline(line, 1e7, opts)
+            if len(got) != 2 || got[0] != line[0] || got[1] != line[len(line)-1] {
+                t.Errorf("%+v: got %v, want only the endpoints", opts, got)
+            }
+            if got := SimplifyPolyline(line[:1], 1e7, opts); len(got) != 1 {
+                t.Errorf("%+v: single point gave %v", opts, got)
+            }
+            if got := SimplifyPolyline(nil, 1e7, opts); len(got) != 0 {
+                t.Errorf("%+v: empty line gave %v", opts, got)
+            }
+        }
+    }
+}
+
+func TestSimplifyRingMinimum(t *testing.T) {
+    star := starRing(Point{0, 0}, 100, 1)
+    closed := append(append([]Point(nil), star...), star[0])
+    triangle := []Point{{0, 0}, {0, 1}, {1, 0}, {0, 0}}
+    for _, method := range []SimplifyMethod{DouglasPeucker, VisvalingamWhyatt} {
+        opts := SimplifyOptions{Method: method}
+        got := SimplifyRing(closed, 1e7, opts)
+        if len(got) != 4 || got[0] != got[3] {
+            t.Errorf("method %d closed: got %d points, want 3 vertices plus the closing point", method, len(got))
+        }
+        if planarArea(got[:3]) == 0 {
+            t.Errorf("method %d: simplified ring %v has no area", method, got)
+        }
+        if got := SimplifyRing(star, 1e7, opts); len(got) != 3 {
+            t.Errorf("method %d open: got %d points, want 3", method, len(got))
+        }
+        if got := SimplifyRing(triangle, 1e7, opts); len(got) != 4 {
+            t.Errorf("method %d: triangle changed to %v", method, got)
+        }
+        if got := SimplifyRing(closed, 0, opts); len(got) != len(closed) {
+            t.Errorf("method %d zero tolerance: got %d points, want %d", method, len(got), len(closed))
+        }
+    }
+}
+
+func TestSimplifyPreserveTopology(t *testing.T) {
+    rng := rand.New(rand.NewSource(10))
+    // Turns about 1.1 km apart, simplified with a tolerance spanning several turns
+    line := spiral(rng, Point{45, 179.9}, 6, 60, 0.01)
+    for i := range line {
+        line[i].Lon = angNormalize(line[i].Lon)
+    }
+    unwrapped := func(l []Point) []Point {
+        out := append([]Point(nil), l...)
+        for i := 1; i < len(out); i++ {
+            out[i].Lon = out[i-1].Lon + angNormalize(out[i].Lon-out[i-1].Lon)
+        }
+        return out
+    }
+    if selfIntersects(unwrapped(line)) {
+        t.Fatal("test spiral crosses itself")
+    }
+
+    for _, method := range []SimplifyMethod{DouglasPeucker, VisvalingamWhyatt} {
+        plain := SimplifyPolyline(line, 3000, SimplifyOptions{Method: method})
+        if !selfIntersects(unwrapped(plain)) {
+            t.Errorf("method %d: spiral does not exercise topology preservation", method)
+        }
+        got := SimplifyPolyline(line, 3000, SimplifyOptions{Method: method, PreserveTopology: true})
+        if selfIntersects(unwrapped(got)) {
+            t.Errorf("method %d: simplified line crosses itself", method)
+        }
+        if len(got) >= len(line) || got[0] != line[0] || got[len(got)-1] != line[len(line)-1] {
+            t.Errorf("method %d: got %d of %d points", method, len(got), len(line))
+        }
+    }
+}
+
+func TestPolygonSimplifyPreserveTopology(t *testing.T) {
+    // A bump on the east edge holds a small hole that straightening the edge would cut
+    bumpLon := 1 + metersToDegrees(5000)
+    pg := Polygon{
+        Outer: []Point{{0, 0}, {0, 1}, {0.45, 1}, {0.5, bumpLon}, {0.55, 1}, {1, 1}, {1, 0}},
+        Holes: [][]Point{{{0.49, 1.01}, {0.51, 1.01}, {0.51, 1.02}, {0.49, 1.02}}},
+    }
+    closed := func(r []Point) []Point { return append(append([]Point(nil), r...), r[0]) }
+
+    for _, method := range []SimplifyMethod{DouglasPeucker, VisvalingamWhyatt} {
+        plain := pg.Simplify(10000, SimplifyOptions{Method: method})
+        if IsPointInPolygon(plain.Holes[0][0], plain.Outer) {
+            t.Errorf("method %d: fixture does not exercise topology preservation", method)
+        }
+        got := pg.Simplify(10000, SimplifyOptions{Method: method, PreserveTopology: true})
+        if selfIntersects(closed(got.Outer), closed(got.Holes[0])) {
+            t.Errorf("method %d: outer ring %v crosses the hole", method, got.Outer)
+        }
+        for _, v := range got.Holes[0] {
+            if !IsPointInPolygon(v, got.Outer) {
+                t.Errorf("method %d: hole vertex %v outside the simplified outer ring", method, v)
+            }
+        }
+        if !slicesContain(got.Outer, pg.Outer[3]) {
+            t.Errorf("method %d: bump around the hole removed from %v", method, got.Outer)
+        }
+    }
+}
+
+func TestPolygonSimplifyHoleAcrossEdge(t *testing.T) {
+    // Straightening the bump would cut through the hole
+    bumpLon := 1 + metersToDegrees(5000)
+    pg := Polygon{
+        Outer: []Point{{0, 0}, {0, 1}, {0.45, 1}, {0.5, bumpLon}, {0.55, 1}, {1, 1}, {1, 0}},
+        Holes: [][]Point{{{0.49, 0.99}, {0.51, 0.99}, {0.51, 1.02}, {0.49, 1.02}}},
+    }
+    closed := func(r []Point) []Point { return append(append([]Point(nil), r...), r[0]) }
+    for _, method := range []SimplifyMethod{DouglasPeucker, VisvalingamWhyatt} {
+        plain := pg.Simplify(10000, SimplifyOptions{Method: method})
+        if !selfIntersects(closed(plain.Outer), closed(plain.Holes[0])) {
+            t.Errorf("method %d: fixture does not exercise topology preservation", method)
+        }
+        got := pg.Simplify(10000, SimplifyOptions{Method: method, PreserveTopology: true})
+        if selfIntersects(closed(got.Outer), closed(got.Holes[0])) {
+            t.Errorf("method %d: outer ring %v crosses the hole", method, got.Outer)
+        }
+    }
+}
+
+func TestBatchSimplify(t *testing.T) {
+    rng := rand.New(rand.NewSource(11))
+    lines := make([][]Point, 30)
+    for i := range lines {
+        lines[i] = randomPoints(rng, Point{0, 0}, 50, 0.1)
+    }
+    got := BatchSimplify(lines, 500, SimplifyOptions{})
+    for i, l := range lines {
+        want := SimplifyPolyline(l, 500, SimplifyOptions{})
+        if len(got[i]) != len(want) {
+            t.Errorf("line %d: got %d points, want %d", i, len(got[i]), len(want))
+        }
+    }
+}
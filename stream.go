@@ -0,0 +1,335 @@
+package geoutil
+
+import (
+    "bufio"
+    "context"
+    "encoding/binary"
+    "errors"
+    "io"
+    "iter"
+    "math"
+    "sync"
+    "sync/atomic"
+)
+
+// MatrixTile is a rectangular block of a distance matrix
+type MatrixTile struct {
+    RowStart int       // Index of the first row (point) in the tile
+    ColStart int       // Index of the first column (point) in the tile
+    Rows     int       // Number of rows in the tile
+    Cols     int       // Number of columns in the tile
+    Data     []float64 // Row-major tile values
+}
+
+// At returns the distance between points RowStart+i and ColStart+j
+func (t MatrixTile) At(i, j int) float64 {
+    return t.Data[i*t.Cols+j]
+}
+
+// DistancePair is a pair of point indexes with their distance
+type DistancePair struct {
+    I, J     int     // Point indexes with I < J
+    Distance float64 // Distance between the points
+}
+
+// StreamDistanceTiles computes the upper triangle of an NxN matrix tile by tile
+// Tiles are computed concurrently and passed to fn one at a time in no particular order
+// Diagonal tiles are full squares; tile data is only valid during the callback
+// ctx: Context for cancellation
+// points: Slice of geographic points
+// distanceFunc: Distance calculation function
+// tileSize: Tile edge length in points (default 256)
+// opts: Worker options (Float32 and ChunkRows are ignored)
+// fn: Tile consumer; a returned error stops the computation
+// Returns: ErrInvalidCoordinate, or the first error from fn or the context
+func StreamDistanceTiles(ctx context.Context, points []Point, distanceFunc func(p1, p2 Point) float64, tileSize int, opts MatrixOptions, fn func(MatrixTile) error) error {
+    if err := validatePoints(points); err != nil {
+        return err
+    }
+    if tileSize <= 0 {
+        tileSize = 256
+    }
+    n := len(points)
+    blocks := (n + tileSize - 1) / tileSize
+    // Upper-triangle tiles (bi <= bj) are numbered row by row
+    total := blocks * (blocks + 1) / 2
+    opts = opts.withDefaults(total)
+
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+
+    pool := sync.Pool{New: func() any { return make([]float64, tileSize*tileSize) }}
+    tiles := make(chan MatrixTile, opts.Workers)
+    var next atomic.Int64
+    var wg sync.WaitGroup
+
+    workers := min(opts.Workers, max(1, total))
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for ctx.Err() == nil {
+                t := int(next.Add(1)) - 1
+                if t >= total {
+                    return
+                }
+                bi, bj := tileCoords(t, blocks)
+                tile := MatrixTile{RowStart: bi * tileSize, ColStart: bj * tileSize}
+                tile.Rows = min(tileSize, n-tile.RowStart)
+                tile.Cols = min(tileSize, n-tile.ColStart)
+                tile.Data = pool.Get().([]float64)[:tile.Rows*tile.Cols]
+                for i := 0; i < tile.Rows; i++ {
+                    p := points[tile.RowStart+i]
+                    row := tile.Data[i*tile.Cols : (i+1)*tile.Cols]
+                    for j := range row {
+                        row[j] = distanceFunc(p, points[tile.ColStart+j])
+                    }
+                }
+                select {
+                case tiles <- tile:
+                case <-ctx.Done():
+                    return
+                }
+            }
+        }()
+    }
+
+    go func() {
+        wg.Wait()
+        close(tiles)
+    }()
+
+    var err error
+    delivered := 0
+    for tile := range tiles {
+        if err == nil {
+            if err = fn(tile); err != nil {
+                cancel()
+            }
+            delivered++
+        }
+        pool.Put(tile.Data[:cap(tile.Data)])
+    }
+    if err != nil {
+        return err
+    }
+    if delivered < total {
+        return ctx.Err()
+    }
+    return nil
+}
+
+// tileCoords maps an upper-triangle tile number to block coordinates
+func tileCoords(t, blocks int) (int, int) {
+    bi := 0
+    for rowLen := blocks; t >= rowLen; rowLen-- {
+        t -= rowLen
+        bi++
+    }
+    return bi, bi + t
+}
+
+// rowBufferBytes caps the memory DistanceRows holds for a batch of rows
+const rowBufferBytes = 32 << 20
+
+// DistanceRows streams the rows of the NxN distance matrix in order
+// Rows are computed concurrently in batches of at most 32 MiB; the yielded slice is
+// reused between rows. Every row is computed in full, so this does about twice the
+// distance evaluations of StreamDistanceTiles, which only covers the upper triangle
+// Iteration stops early if ctx is cancelled; check ctx.Err() after the loop
+// ctx: Context for cancellation
+// points: Slice of geographic points
+// distanceFunc: Distance calculation function
+// opts: Worker options; ChunkRows defaults to 1 to bound the row buffer (Float32 is ignored)
+// Returns: Iterator over (row index, row distances), or ErrInvalidCoordinate before any work is done
+func DistanceRows(ctx context.Context, points []Point, distanceFunc func(p1, p2 Point) float64, opts MatrixOptions) (iter.Seq2[int, []float64], error) {
+    if err := validatePoints(points); err != nil {
+        return nil, err
+    }
+    return func(yield func(int, []float64) bool) {
+        n := len(points)
+        if opts.ChunkRows <= 0 {
+            // Keep the row buffer small: one row per worker claim
+            opts.ChunkRows = 1
+        }
+        opts = opts.withDefaults(n)
+        batch := max(1, min(n, opts.Workers*opts.ChunkRows*4, rowBufferBytes/(8*max(1, n))))
+        buf := make([]float64, batch*n)
+
+        for start := 0; start < n; start += batch {
+            end := min(start+batch, n)
+            err := forEachRow(ctx, end-start, opts, func(r int) {
+                i := start + r
+                row := buf[r*n : (r+1)*n]
+                for j, p := range points {
+                    if j == i {
+                        row[j] = 0
+                        continue
+                    }
+                    row[j] = distanceFunc(points[i], p)
+                }
+            })
+            if err != nil {
+                return
+            }
+            for i := start; i < end; i++ {
+                r := i - start
+                if !yield(i, buf[r*n:(r+1)*n:(r+1)*n]) {
+                    return
+                }
+            }
+        }
+    }, nil
+}
+
+// PairsWithin streams all point pairs closer than a threshold without storing the matrix
+// Pairs are produced tile by tile in no particular order
+// Iteration stops early if ctx is cancelled; check ctx.Err() after the loop
+// ctx: Context for cancellation
+// points: Slice of geographic points
+// distanceFunc: Distance calculation function
+// threshold: Maximum distance (inclusive) in distanceFunc units
+// opts: Worker options
+// Returns: Iterator over pairs with I < J, or ErrInvalidCoordinate before any work is done
+func PairsWithin(ctx context.Context, points []Point, distanceFunc func(p1, p2 Point) float64, threshold float64, opts MatrixOptions) (iter.Seq[DistancePair], error) {
+    if err := validatePoints(points); err != nil {
+        return nil, err
+    }
+    errStop := errors.New("stop")
+    return func(yield func(DistancePair) bool) {
+        StreamDistanceTiles(ctx, points, distanceFunc, 0, opts, func(t MatrixTile) error {
+            for i := 0; i < t.Rows; i++ {
+                for j := 0; j < t.Cols; j++ {
+                    pi, pj := t.RowStart+i, t.ColStart+j
+                    if pj <= pi || t.At(i, j) > threshold {
+                        continue
+                    }
+                    if !yield(DistancePair{pi, pj, t.At(i, j)}) {
+                        return errStop
+                    }
+                }
+            }
+            return nil
+        })
+    }, nil
+}
+
+// Binary matrix file layout: 8-byte magic, uint8 element size (4 or 8),
+// uint64 rows, uint64 cols, then row-major little-endian IEEE 754 values
+const (
+    matrixMagic      = "GEOUTILM"
+    matrixHeaderSize = len(matrixMagic) + 1 + 8 + 8
+)
+
+// ErrInvalidMatrixFile is returned when a binary matrix file has a bad header
+var ErrInvalidMatrixFile = errors.New("invalid distance matrix file")
+
+// WriteDistanceMatrix streams the NxN distance matrix to w in a compact binary format
+// Only a batch of rows is held in memory at a time
+// ctx: Context for cancellation
+// w: Destination, e.g. an *os.File
+// points: Slice of geographic points
+// distanceFunc: Distance calculation function
+// opts: Worker options; Float32 selects 4-byte values
+// Returns: ErrInvalidCoordinate, write or context error; nothing is written for invalid points
+func WriteDistanceMatrix(ctx context.Context, w io.Writer, points []Point, distanceFunc func(p1, p2 Point) float64, opts MatrixOptions) error {
+    rows, err := DistanceRows(ctx, points, distanceFunc, opts)
+    if err != nil {
+        return err
+    }
+    bw := bufio.NewWriter(w)
+    size := 8
+    if opts.Float32 {
+        size = 4
+    }
+
+    header := make([]byte, matrixHeaderSize)
+    copy(header, matrixMagic)
+    header[len(matrixMagic)] = byte(size)
+    binary.LittleEndian.PutUint64(header[len(matrixMagic)+1:], uint64(len(points)))
+    binary.LittleEndian.PutUint64(header[len(matrixMagic)+9:], uint64(len(points)))
+    if _, err := bw.Write(header); err != nil {
+        return err
+    }
+
+    buf := make([]byte, len(points)*size)
+    for _, row := range rows {
+        for j, v := range row {
+            if opts.Float32 {
+                binary.LittleEndian.PutUint32(buf[j*4:], math.Float32bits(float32(v)))
+            } else {
+                binary.LittleEndian.PutUint64(buf[j*8:], math.Float64bits(v))
+            }
+        }
+        if _, err = bw.Write(buf); err != nil {
+            break
+        }
+    }
+    if err != nil {
+        return err
+    }
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    return bw.Flush()
+}
+
+// MatrixFile provides random row access to a binary matrix written by WriteDistanceMatrix
+type MatrixFile struct {
+    Rows    int  // Number of rows
+    Cols    int  // Number of columns
+    Float32 bool // Values are stored as float32
+    r       io.ReaderAt
+}
+
+// OpenMatrixFile reads the header of a binary distance matrix
+// r: Source, e.g. an *os.File
+// Returns: Matrix file handle or ErrInvalidMatrixFile
+func OpenMatrixFile(r io.ReaderAt) (*MatrixFile, error) {
+    header := make([]byte, matrixHeaderSize)
+    if _, err := r.ReadAt(header, 0); err != nil {
+        return nil, err
+    }
+    if string(header[:len(matrixMagic)]) != matrixMagic {
+        return nil, ErrInvalidMatrixFile
+    }
+    size := header[len(matrixMagic)]
+    if size != 4 && size != 8 {
+        return nil, ErrInvalidMatrixFile
+    }
+    return &MatrixFile{
+        Rows:    int(binary.LittleEndian.Uint64(header[len(matrixMagic)+1:])),
+        Cols:    int(binary.LittleEndian.Uint64(header[len(matrixMagic)+9:])),
+        Float32: size == 4,
+        r:       r,
+    }, nil
+}
+
+// Row reads row i into dst, which is grown as needed
+// i: Row index
+// dst: Optional buffer to reuse
+// Returns: Row values or read error
+func (f *MatrixFile) Row(i int, dst []float64) ([]float64, error) {
+    size := 8
+    if f.Float32 {
+        size = 4
+    }
+    buf := make([]byte, f.Cols*size)
+    offset := int64(matrixHeaderSize) + int64(i)*int64(f.Cols)*int64(size)
+    if _, err := f.r.ReadAt(buf, offset); err != nil {
+        return nil, err
+    }
+
+    if cap(dst) < f.Cols {
+        dst = make([]float64, f.Cols)
+    }
+    dst = dst[:f.Cols]
+    for j := range dst {
+        if f.Float32 {
+            dst[j] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[j*4:])))
+        } else {
+            dst[j] = math.Float64frombits(binary.LittleEndian.Uint64(buf[j*8:]))
+        }
+    }
+    return dst, nil
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package geoutil
+
+import (
+    "context"
+    "errors"
+    "math"
+    "math/rand"
+    "testing"
+)
+
+func TestDistanceRowsMatchesMatrix(t *testing.T) {
+    pts := randomPoints(rand.New(rand.NewSource(1)), Point{48, 11}, 300, 5)
+    m, err := ComputeDistanceMatrix(context.Background(), pts, DistanceHaversine, MatrixOptions{})
+    if err != nil {
+        t.Fatal(err)
+    }
+    rows, err := DistanceRows(context.Background(), pts, DistanceHaversine, MatrixOptions{Workers: 3})
+    if err != nil {
+        t.Fatal(err)
+    }
+    seen := 0
+    for i, row := range rows {
+        if i != seen {
+            t.Fatalf("got row %d, want %d", i, seen)
+        }
+        for j, v := range row {
+            if v != m.At(i, j) {
+                t.Fatalf("row %d col %d = %v, want %v", i, j, v, m.At(i, j))
+            }
+        }
+        seen++
+    }
+    if seen != len(pts) {
+        t.Fatalf("got %d rows, want %d", seen, len(pts))
+    }
+}
+
+func TestMatrixInvalidPoints(t *testing.T) {
+    pts := []Point{{0, 0}, {math.NaN(), 1}}
+    if _, err := DistanceRows(context.Background(), pts, DistanceHaversine, MatrixOptions{}); !errors.Is(err, ErrInvalidCoordinate) {
+        t.Errorf("DistanceRows error = %v, want ErrInvalidCoordinate", err)
+    }
+    if _, err := PairsWithin(context.Background(), pts, DistanceHaversine, 1, MatrixOptions{}); !errors.Is(err, ErrInvalidCoordinate) {
+        t.Errorf("PairsWithin error = %v, want ErrInvalidCoordinate", err)
+    }
+    if _, err := ComputeDistanceMatrix(context.Background(), pts, DistanceHaversine, MatrixOptions{}); !errors.Is(err, ErrInvalidCoordinate) {
+        t.Errorf("ComputeDistanceMatrix error = %v, want ErrInvalidCoordinate", err)
+    }
+    // The legacy wrappers stay total and compute whatever distanceFunc returns
+    wrapped := []Point{{0, 0}, {0, 190}}
+    m := BatchDistanceConcurrent(wrapped, DistanceHaversine)
+    if len(m) != 2 || math.Abs(m[0][1]-DistanceHaversine(wrapped[0], wrapped[1])) > 1e-9 {
+        t.Errorf("BatchDistanceConcurrent = %v, want a 2x2 matrix", m)
+    }
+    if m := DistanceMatrixConcurrent(pts, wrapped, DistanceHaversine); len(m) != 2 || len(m[1]) != 2 {
+        t.Errorf("DistanceMatrixConcurrent = %v, want a 2x2 matrix", m)
+    }
+}
@@ -0,0 +1,93 @@
+package geoutil
+
+import "math"
+
+// PolylineProjection describes the closest point of a polyline to a query point
+type PolylineProjection struct {
+    Index    int     // Index of the segment start vertex (segment Index..Index+1)
+    Point    Point   // Closest point on the polyline
+    Distance float64 // Distance from the query point in kilometers
+    Along    float64 // Distance from the polyline start to Point in kilometers
+}
+
+// CrossTrackDistance calculates the distance from a point to the great circle through start and end
+// p: Point to measure
+// start, end: Points defining the great-circle path
+// Returns: Signed distance in kilometers (negative left of path, positive right)
+func CrossTrackDistance(p, start, end Point) float64 {
+    δ13 := DistanceHaversine(start, p) / EarthSphere.Radius
+    θ13 := InitialBearing(start, p) * degToRad
+    θ12 := InitialBearing(start, end) * degToRad
+
+    return math.Asin(clampUnit(math.Sin(δ13)*math.Sin(θ13-θ12))) * EarthSphere.Radius
+}
+
+// AlongTrackDistance calculates the distance from start to the foot of the perpendicular from p
+// p: Point to project
+// start, end: Points defining the great-circle path
+// Returns: Signed distance in kilometers (negative if the foot lies behind start)
+func AlongTrackDistance(p, start, end Point) float64 {
+    δ13 := DistanceHaversine(start, p) / EarthSphere.Radius
+    θ13 := InitialBearing(start, p) * degToRad
+    θ12 := InitialBearing(start, end) * degToRad
+    δxt := math.Asin(clampUnit(math.Sin(δ13) * math.Sin(θ13-θ12)))
+
+    δat := math.Acos(clampUnit(math.Cos(δ13) / math.Cos(δxt)))
+    return math.Copysign(δat, math.Cos(θ13-θ12)) * EarthSphere.Radius
+}
+
+// ClosestPointOnSegment finds the point of the great-circle segment nearest to p
+// p: Query point
+// start, end: Segment endpoints
+// Returns: Closest point and its distance from p in kilometers
+func ClosestPointOnSegment(p, start, end Point) (Point, float64) {
+    length := DistanceHaversine(start, end)
+    if length == 0 {
+        return start, DistanceHaversine(p, start)
+    }
+
+    along := AlongTrackDistance(p, start, end)
+    switch {
+    case along <= 0:
+        return start, DistanceHaversine(p, start)
+    case along >= length:
+        return end, DistanceHaversine(p, end)
+    }
+
+    closest := Destination(start, InitialBearing(start, end), along)
+    return closest, DistanceHaversine(p, closest)
+}
+
+// NearestOnPolyline projects a point onto the nearest segment of a polyline
+// p: Query point
+// line: Polyline vertices (at least 1 point)
+// Returns: Nearest segment index, projected point and distances; Index is -1 for an empty line
+func NearestOnPolyline(p Point, line []Point) PolylineProjection {
+    best := PolylineProjection{Index: -1, Distance: math.Inf(1)}
+    switch len(line) {
+    case 0:
+        return best
+    case 1:
+        return PolylineProjection{Index: 0, Point: line[0], Distance: DistanceHaversine(p, line[0])}
+    }
+
+    travelled := 0.0
+    for i := 0; i < len(line)-1; i++ {
+        closest, dist := ClosestPointOnSegment(p, line[i], line[i+1])
+        if dist < best.Distance {
+            best = PolylineProjection{
+                Index:    i,
+                Point:    closest,
+                Distance: dist,
+                Along:    travelled + DistanceHaversine(line[i], closest),
+            }
+        }
+        travelled += DistanceHaversine(line[i], line[i+1])
+    }
+    return best
+}
+
+// clampUnit limits x to [-1, 1] to guard inverse trigonometric functions
+func clampUnit(x float64) float64 {
+    return math.Max(-1, math.Min(1, x))
+}
\ No newline at end of file
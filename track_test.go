@@ -0,0 +1,135 @@
+package geoutil
+
+import (
+    "math"
+    "testing"
+)
+
+// Veness's cross-track example: a point near a path from Sheffield towards Skegness
+var (
+    trackPoint = Point{53.2611, -0.7972}
+    trackStart = Point{53.3206, -1.7297}
+    trackEnd   = Point{53.1887, 0.1334}
+)
+
+func TestCrossTrackDistance(t *testing.T) {
+    tests := []struct {
+        name          string
+        p, start, end Point
+        want          float64
+        tol           float64
+    }{
+        {"Veness example", trackPoint, trackStart, trackEnd, -0.3075, 5e-5},
+        {"right of path", Point{-1, 5}, Point{0, 0}, Point{0, 10}, 1 * EarthSphere.Radius * degToRad, 1e-9},
+        {"left of path", Point{1, 5}, Point{0, 0}, Point{0, 10}, -1 * EarthSphere.Radius * degToRad, 1e-9},
+        {"on path", Point{0, 5}, Point{0, 0}, Point{0, 10}, 0, 1e-9},
+        // The great circle extends beyond the endpoints
+        {"beyond end", Point{1, 20}, Point{0, 0}, Point{0, 10}, -1 * EarthSphere.Radius * degToRad, 1e-9},
+        {"across antimeridian", Point{1, 180}, Point{0, 175}, Point{0, -175}, -1 * EarthSphere.Radius * degToRad, 1e-9},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := CrossTrackDistance(tt.p, tt.start, tt.end); math.Abs(got-tt.want) > tt.tol {
+                t.Errorf("CrossTrackDistance = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestAlongTrackDistance(t *testing.T) {
+    oneDegree := EarthSphere.Radius * degToRad
+    tests := []struct {
+        name          string
+        p, start, end Point
+        want          float64
+        tol           float64
+    }{
+        {"Veness example", trackPoint, trackStart, trackEnd, 62.331, 5e-4},
+        {"halfway", Point{0, 5}, Point{0, 0}, Point{0, 10}, 5 * oneDegree, 1e-9},
+        {"beside start", Point{1, 0}, Point{0, 0}, Point{0, 10}, 0, 1e-9},
+        {"behind start", Point{0, -2}, Point{0, 0}, Point{0, 10}, -2 * oneDegree, 1e-9},
+        {"past end", Point{0, 12}, Point{0, 0}, Point{0, 10}, 12 * oneDegree, 1e-9},
+        {"across antimeridian", Point{0.5, -178}, Point{0, 175}, Point{0, -175}, 7 * oneDegree, 1e-3},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := AlongTrackDistance(tt.p, tt.start, tt.end); math.Abs(got-tt.want) > tt.tol {
+                t.Errorf("AlongTrackDistance = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestClosestPointOnSegment(t *testing.T) {
+    start, end := Point{0, 0}, Point{0, 10}
+    tests := []struct {
+        name string
+        p    Point
+        want Point
+    }{
+        {"projects inside", Point{1, 4}, Point{0, 4}},
+        {"clamps before start", Point{1, -3}, start},
+        {"clamps after end", Point{-2, 13}, end},
+        {"at start", start, start},
+        {"on segment", Point{0, 7}, Point{0, 7}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, d := ClosestPointOnSegment(tt.p, start, end)
+            if !got.Equal(tt.want, 1e-3) {
+                t.Errorf("closest point = %v, want %v", got, tt.want)
+            }
+            if want := DistanceHaversine(tt.p, tt.want); math.Abs(d-want) > 1e-6 {
+                t.Errorf("distance = %v, want %v", d, want)
+            }
+        })
+    }
+
+    // Inside the segment the distance is the cross-track distance
+    _, d := ClosestPointOnSegment(trackPoint, trackStart, trackEnd)
+    if want := math.Abs(CrossTrackDistance(trackPoint, trackStart, trackEnd)); math.Abs(d-want) > 1e-9 {
+        t.Errorf("distance = %v, want cross-track %v", d, want)
+    }
+    // A degenerate segment is its start point
+    if got, d := ClosestPointOnSegment(Point{1, 1}, start, start); got != start || math.Abs(d-DistanceHaversine(Point{1, 1}, start)) > 1e-12 {
+        t.Errorf("zero-length segment: got %v, %v", got, d)
+    }
+}
+
+func TestNearestOnPolyline(t *testing.T) {
+    oneDegree := EarthSphere.Radius * degToRad
+    line := []Point{{0, 0}, {0, 10}, {10, 10}}
+    // The foot of the perpendicular on a meridian lies slightly poleward of the query latitude
+    foot, footDist := ClosestPointOnSegment(Point{5, 11}, line[1], line[2])
+    tests := []struct {
+        name  string
+        p     Point
+        index int
+        point Point
+        dist  float64
+        along float64
+    }{
+        {"first segment", Point{-1, 5}, 0, Point{0, 5}, oneDegree, 5 * oneDegree},
+        {"second segment", Point{5, 11}, 1, foot, footDist, 10*oneDegree + DistanceHaversine(line[1], foot)},
+        {"clamped to start", Point{0, -2}, 0, Point{0, 0}, 2 * oneDegree, 0},
+        {"clamped to end", Point{12, 10}, 1, Point{10, 10}, 2 * oneDegree, 20 * oneDegree},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := NearestOnPolyline(tt.p, line)
+            if got.Index != tt.index || !got.Point.Equal(tt.point, 1e-3) {
+                t.Errorf("got segment %d at %v, want %d at %v", got.Index, got.Point, tt.index, tt.point)
+            }
+            if math.Abs(got.Distance-tt.dist) > 1e-6 || math.Abs(got.Along-tt.along) > 1e-6 {
+                t.Errorf("distance %v along %v, want %v along %v", got.Distance, got.Along, tt.dist, tt.along)
+            }
+        })
+    }
+
+    if got := NearestOnPolyline(Point{1, 1}, nil); got.Index != -1 {
+        t.Errorf("empty line: Index = %d, want -1", got.Index)
+    }
+    if got := NearestOnPolyline(Point{1, 1}, line[:1]); got.Index != 0 || got.Point != line[0] {
+        t.Errorf("single point: got %+v", got)
+    }
+}
@@ -1,7 +1,10 @@
 // Package geoutil provides advanced geospatial utilities with optimized concurrent processing.
 package geoutil
 
-import "time"
+import (
+    "context"
+    "time"
+)
 
 // Point represents a geographic coordinate
 type Point struct {
@@ -35,8 +38,25 @@ type Geocoder interface {
     BatchReverseGeocode(points []Point) ([]Location, error)  // Batch reverse geocoding
 }
 
+// GeocoderContext extends Geocoder with context-aware operations
+// The context controls rate-limiter waits, HTTP requests and batch workers
+type GeocoderContext interface {
+    Geocoder
+    GeocodeContext(ctx context.Context, address string) (Point, error)
+    ReverseGeocodeContext(ctx context.Context, p Point) (Location, error)
+    BatchGeocodeContext(ctx context.Context, addresses []string) ([]Point, error)
+    BatchReverseGeocodeContext(ctx context.Context, points []Point) ([]Location, error)
+}
+
 // ElevationProvider interface defines elevation data operations
 type ElevationProvider interface {
     GetElevation(p Point) (int, error)              // Get elevation for single point
     BatchGetElevation(points []Point) ([]int, error) // Batch elevation processing
+}
+
+// ElevationProviderContext extends ElevationProvider with context-aware operations
+type ElevationProviderContext interface {
+    ElevationProvider
+    GetElevationContext(ctx context.Context, p Point) (int, error)
+    BatchGetElevationContext(ctx context.Context, points []Point) ([]int, error)
 }
\ No newline at end of file
@@ -0,0 +1,484 @@
+package geoutil
+
+import (
+    "fmt"
+    "math"
+    "sort"
+)
+
+// ValidationReason identifies what is wrong with a polygon
+type ValidationReason int
+
+const (
+    // InvalidCoordinate marks a vertex that is NaN, infinite or out of range
+    InvalidCoordinate ValidationReason = iota
+    // TooFewPoints marks a ring with fewer than 3 distinct vertices
+    TooFewPoints
+    // RingNotClosed marks a ring whose last vertex differs from the first
+    RingNotClosed
+    // DuplicateVertex marks a vertex equal to the one before it
+    DuplicateVertex
+    // SelfIntersection marks a ring crossing or touching itself, including spikes
+    SelfIntersection
+    // RingsIntersect marks a hole crossing or overlapping the outer ring or another hole
+    // Rings touching at a single point are allowed
+    RingsIntersect
+    // HoleOutside marks a hole that lies outside the outer ring
+    HoleOutside
+    // WrongWinding marks an outer ring that is not counter-clockwise or a hole that is not clockwise
+    WrongWinding
+)
+
+var validationReasonNames = [...]string{
+    InvalidCoordinate: "invalid coordinate",
+    TooFewPoints:      "too few points",
+    RingNotClosed:     "ring not closed",
+    DuplicateVertex:   "duplicate vertex",
+    SelfIntersection:  "self-intersection",
+    RingsIntersect:    "rings intersect",
+    HoleOutside:       "hole outside outer ring",
+    WrongWinding:      "wrong winding",
+}
+
+// String returns a readable name of the reason
+func (r ValidationReason) String() string {
+    if r >= 0 && int(r) < len(validationReasonNames) {
+        return validationReasonNames[r]
+    }
+    return fmt.Sprintf("ValidationReason(%d)", int(r))
+}
+
+// ValidateOptions enables checks for conventions that the rest of the package does not require
+type ValidateOptions struct {
+    RequireClosed  bool // Report rings that do not repeat the first vertex at the end
+    RequireWinding bool // Report outer rings that are not counter-clockwise and holes that are not clockwise
+}
+
+// ValidationIssue describes a single problem found by Validate
+type ValidationIssue struct {
+    Reason  ValidationReason
+    Polygon int   // Index of the polygon in a MultiPolygon, 0 for a Polygon
+    Ring    int   // 0 for the outer ring, i+1 for Holes[i]
+    Vertex  int   // Index of the offending vertex or of the first vertex of the offending edge
+    Point   Point // Offending vertex or intersection location
+}
+
+// String describes the issue and where it occurs
+func (i ValidationIssue) String() string {
+    switch i.Reason {
+    case InvalidCoordinate, DuplicateVertex:
+        return fmt.Sprintf("%s at vertex %d of ring %d", i.Reason, i.Vertex, i.Ring)
+    case SelfIntersection, RingsIntersect:
+        return fmt.Sprintf("%s at (%g, %g) in ring %d", i.Reason, i.Point.Lat, i.Point.Lon, i.Ring)
+    }
+    return fmt.Sprintf("%s in ring %d", i.Reason, i.Ring)
+}
+
+// ValidationError lists every issue found in a polygon
+type ValidationError struct {
+    Issues []ValidationIssue
+}
+
+// Error summarizes the issues
+func (e *ValidationError) Error() string {
+    if len(e.Issues) == 1 {
+        return "invalid polygon: " + e.Issues[0].String()
+    }
+    return fmt.Sprintf("invalid polygon: %d issues (first: %v)", len(e.Issues), e.Issues[0])
+}
+
+// ValidatePolygon checks a single ring for problems that make containment results unreliable
+// polygon: Ring vertices
+// opts: Optional closing and winding checks
+// Returns: nil or *ValidationError
+func ValidatePolygon(polygon []Point, opts ValidateOptions) error {
+    return Polygon{Outer: polygon}.Validate(opts)
+}
+
+// Validate checks the polygon for invalid coordinates, degenerate rings, duplicate
+// vertices, self-intersections and holes crossing or outside the outer ring
+// Intersections are found in the coordinate frame of the polygon's Mode
+// opts: Optional closing and winding checks
+// Returns: nil or *ValidationError listing every issue
+func (pg Polygon) Validate(opts ValidateOptions) error {
+    if issues := pg.issues(0, opts); len(issues) > 0 {
+        return &ValidationError{Issues: issues}
+    }
+    return nil
+}
+
+// Validate checks every polygon; overlaps between members are not reported
+// opts: Optional closing and winding checks
+// Returns: nil or *ValidationError with Polygon set on each issue
+func (mp MultiPolygon) Validate(opts ValidateOptions) error {
+    var issues []ValidationIssue
+    for i, pg := range mp {
+        issues = append(issues, pg.issues(i, opts)...)
+    }
+    if len(issues) > 0 {
+        return &ValidationError{Issues: issues}
+    }
+    return nil
+}
+
+// MakeValidPolygon repairs a single ring
+// polygon: Ring vertices
+// Returns: Valid polygons covering what IsPointInPolygon reports as inside
+func MakeValidPolygon(polygon []Point) MultiPolygon {
+    return Polygon{Outer: polygon}.MakeValid()
+}
+
+// MakeValid repairs the polygon into valid polygons covering the same area as Contains
+// Invalid vertices are dropped, latitudes clamped and longitudes wrapped; self-intersecting
+// rings are split at their crossings using the even-odd rule of IsPointInPolygon, and holes
+// are subtracted from the outer ring
+// Returns: Polygons with counter-clockwise outer rings, clockwise holes and no crossings
+func (pg Polygon) MakeValid() MultiPolygon {
+    outer := cleanRing(pg.Outer)
+    var lp localProjection
+    if pg.Mode != ContainmentPlanar {
+        lp = newLocalProjection(outer)
+    }
+    frame := clipTransform(pg.Mode, lp.lon0)
+
+    result := evenOddPolygons(frameRing(outer, frame), pg.Mode)
+    var holes []Polygon
+    for _, hole := range pg.Holes {
+        holes = append(holes, evenOddPolygons(frameRing(cleanRing(hole), frame), pg.Mode)...)
+    }
+    if len(result) == 0 || len(holes) == 0 {
+        return result
+    }
+    return Difference(result, UnionAll(holes))
+}
+
+// MakeValid repairs every polygon and merges overlapping members
+// Returns: Valid, non-overlapping polygons
+func (mp MultiPolygon) MakeValid() MultiPolygon {
+    var parts []Polygon
+    for _, pg := range mp {
+        parts = append(parts, pg.MakeValid()...)
+    }
+    return UnionAll(parts)
+}
+
+// ringEdge is an edge of a polygon ring in the validation frame
+type ringEdge struct {
+    a, b  Point
+    ring  int
+    index int // Index of the first vertex in the original ring
+    seq   int // Position among the ring's non-degenerate edges
+    count int // Number of non-degenerate edges in the ring
+}
+
+// issues collects all validation issues of the polygon
+func (pg Polygon) issues(polygon int, opts ValidateOptions) []ValidationIssue {
+    var issues []ValidationIssue
+    report := func(reason ValidationReason, ring, vertex int, p Point) {
+        issues = append(issues, ValidationIssue{Reason: reason, Polygon: polygon, Ring: ring, Vertex: vertex, Point: p})
+    }
+
+    var lon0 float64
+    if pg.Mode != ContainmentPlanar {
+        lon0 = newLocalProjection(cleanRing(pg.Outer)).lon0
+    }
+    toFrame := func(p Point) Point {
+        if pg.Mode != ContainmentPlanar {
+            p.Lon = lon0 + angNormalize(p.Lon-lon0)
+        }
+        return p
+    }
+    fromFrame := func(p Point) Point {
+        if pg.Mode != ContainmentPlanar {
+            p.Lon = angNormalize(p.Lon)
+        }
+        return p
+    }
+
+    rings := append([][]Point{pg.Outer}, pg.Holes...)
+    framed := make([][]Point, len(rings))
+    var edges []ringEdge
+    for ri, ring := range rings {
+        usable := true
+        for i, p := range ring {
+            if !validCoordinate(p) {
+                report(InvalidCoordinate, ri, i, p)
+                usable = false
+            }
+        }
+        if opts.RequireClosed && len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+            report(RingNotClosed, ri, len(ring)-1, ring[len(ring)-1])
+        }
+
+        open := openRing(ring)
+        distinct := make(map[Point]bool, len(open))
+        for i, p := range open {
+            if i > 0 && p == open[i-1] {
+                report(DuplicateVertex, ri, i, p)
+            }
+            distinct[p] = true
+        }
+        if len(distinct) < 3 {
+            report(TooFewPoints, ri, 0, Point{})
+            usable = false
+        }
+        if !usable {
+            continue
+        }
+
+        framed[ri] = make([]Point, len(open))
+        for i, p := range open {
+            framed[ri][i] = toFrame(p)
+        }
+        if opts.RequireWinding && (planarArea(framed[ri]) > 0) != (ri == 0) {
+            report(WrongWinding, ri, 0, Point{})
+        }
+
+        first := len(edges)
+        for i := range open {
+            a, b := framed[ri][i], framed[ri][(i+1)%len(open)]
+            if a != b {
+                edges = append(edges, ringEdge{a: a, b: b, ring: ri, index: i, seq: len(edges) - first})
+            }
+        }
+        for k := first; k < len(edges); k++ {
+            edges[k].count = len(edges) - first
+        }
+    }
+
+    // Sweep edges ordered by their western end
+    sort.Slice(edges, func(i, j int) bool {
+        return math.Min(edges[i].a.Lon, edges[i].b.Lon) < math.Min(edges[j].a.Lon, edges[j].b.Lon)
+    })
+    crossed := make(map[int]bool)
+    seen := make(map[ValidationIssue]bool)
+    for i, e := range edges {
+        maxLon := math.Max(e.a.Lon, e.b.Lon)
+        for _, f := range edges[i+1:] {
+            if math.Min(f.a.Lon, f.b.Lon) > maxLon {
+                break
+            }
+            x, ok := edgeConflict(e, f)
+            if !ok {
+                continue
+            }
+            issue := ValidationIssue{Reason: SelfIntersection, Polygon: polygon, Ring: e.ring, Vertex: e.index, Point: fromFrame(x)}
+            if e.ring != f.ring {
+                issue.Reason = RingsIntersect
+                if f.ring > e.ring {
+                    issue.Ring, issue.Vertex = f.ring, f.index
+                }
+                crossed[e.ring], crossed[f.ring] = true, true
+            }
+            // Several edge pairs can meet at the same point
+            key := issue
+            key.Vertex = 0
+            if !seen[key] {
+                seen[key] = true
+                issues = append(issues, issue)
+            }
+        }
+    }
+
+    // Holes may touch the outer ring at vertices, so probe the middle of an edge
+    for ri := 1; ri < len(rings); ri++ {
+        if framed[0] == nil || framed[ri] == nil || crossed[ri] {
+            continue
+        }
+        h := framed[ri]
+        k := 0
+        for h[k] == h[(k+1)%len(h)] {
+            k++
+        }
+        a, b := h[k], h[(k+1)%len(h)]
+        probe := Point{Lat: (a.Lat + b.Lat) / 2, Lon: (a.Lon + b.Lon) / 2}
+        if !IsPointInPolygon(probe, framed[0]) {
+            report(HoleOutside, ri, 0, rings[ri][0])
+        }
+    }
+    return issues
+}
+
+// edgeConflict reports whether two ring edges intersect where they should not
+// Consecutive edges may only share their common vertex, unless they fold back onto each other
+// Returns: Intersection location
+func edgeConflict(e, f ringEdge) (Point, bool) {
+    if e.ring == f.ring && e.count > 2 {
+        switch {
+        case e.seq == (f.seq+1)%e.count:
+            return foldBack(f, e)
+        case f.seq == (e.seq+1)%e.count:
+            return foldBack(e, f)
+        }
+    }
+    if !segmentsIntersect(e.a, e.b, f.a, f.b) {
+        return Point{}, false
+    }
+    if e.ring != f.ring && !crossesOrOverlaps(e.a, e.b, f.a, f.b) {
+        // Different rings may touch at a single point
+        return Point{}, false
+    }
+    return intersectionPoint(e.a, e.b, f.a, f.b), true
+}
+
+// crossesOrOverlaps reports whether intersecting segments cross at interior points
+// or share a collinear piece of positive length, rather than just touching
+func crossesOrOverlaps(a, b, c, d Point) bool {
+    d1, d2 := orient(c, d, a), orient(c, d, b)
+    d3, d4 := orient(a, b, c), orient(a, b, d)
+    if d1*d2 < 0 && d3*d4 < 0 {
+        return true
+    }
+    if d1 != 0 || d2 != 0 {
+        return false
+    }
+    if (a == c && b == d) || (a == d && b == c) {
+        return true
+    }
+    inside := func(s, t, p Point) bool { return p != s && p != t && onSegment(s, t, p) }
+    return inside(a, b, c) || inside(a, b, d) || inside(c, d, a) || inside(c, d, b)
+}
+
+// foldBack reports a spike where edge f turns back along the preceding edge e
+func foldBack(e, f ringEdge) (Point, bool) {
+    v := e.b
+    dot := (e.a.Lon-v.Lon)*(f.b.Lon-v.Lon) + (e.a.Lat-v.Lat)*(f.b.Lat-v.Lat)
+    if orient(e.a, v, f.b) == 0 && dot > 0 {
+        return v, true
+    }
+    return Point{}, false
+}
+
+// intersectionPoint locates the intersection of two segments known to intersect
+// For collinear overlaps it returns an endpoint lying on the other segment
+func intersectionPoint(a, b, c, d Point) Point {
+    rx, ry := b.Lon-a.Lon, b.Lat-a.Lat
+    sx, sy := d.Lon-c.Lon, d.Lat-c.Lat
+    if den := rx*sy - ry*sx; den != 0 {
+        t := ((c.Lon-a.Lon)*sy - (c.Lat-a.Lat)*sx) / den
+        t = math.Max(0, math.Min(1, t))
+        return Point{Lat: a.Lat + t*ry, Lon: a.Lon + t*rx}
+    }
+    for _, p := range []Point{c, d} {
+        if onSegment(a, b, p) {
+            return p
+        }
+    }
+    return a
+}
+
+// cleanRing drops non-finite vertices, clamps latitudes and wraps longitudes
+func cleanRing(ring []Point) []Point {
+    out := make([]Point, 0, len(ring))
+    for _, p := range ring {
+        if math.IsNaN(p.Lat) || math.IsNaN(p.Lon) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lon, 0) {
+            continue
+        }
+        out = append(out, Point{Lat: math.Max(-90, math.Min(90, p.Lat)), Lon: angNormalize(p.Lon)})
+    }
+    return out
+}
+
+// frameRing maps a ring to the clipping frame and removes repeated vertices
+func frameRing(ring []Point, frame func(Point) Point) []Point {
+    out := make([]Point, 0, len(ring))
+    for _, p := range ring {
+        q := frame(p)
+        if len(out) == 0 || out[len(out)-1] != q {
+            out = append(out, q)
+        }
+    }
+    return openRing(out)
+}
+
+// evenOddPolygons splits a possibly self-intersecting ring into valid polygons covering
+// the points that an even-odd ray test reports as inside
+func evenOddPolygons(ring []Point, mode ContainmentMode) MultiPolygon {
+    if len(ring) < 3 {
+        return nil
+    }
+    edges := make([]*clipEdge, len(ring))
+    for i := range ring {
+        edges[i] = &clipEdge{a: ring[i], b: ring[(i+1)%len(ring)]}
+    }
+
+    // Sub-edges traversed an even number of times do not separate inside from outside
+    counts := make(map[[2]Point]int)
+    var keys [][2]Point
+    for _, e := range splitEdges(edges) {
+        key := undirectedKey(e.a, e.b)
+        if counts[key] == 0 {
+            keys = append(keys, key)
+        }
+        counts[key]++
+    }
+    var boundary [][2]Point
+    for _, key := range keys {
+        if counts[key]%2 == 1 {
+            boundary = append(boundary, key)
+        }
+    }
+
+    // Orient each boundary edge so that the inside is on its left, casting rays
+    // against edges found through longitude and latitude band indexes
+    lonBands := boundaryBands(boundary, func(p Point) float64 { return p.Lon })
+    latBands := boundaryBands(boundary, func(p Point) float64 { return p.Lat })
+    selected := make([][2]Point, len(boundary))
+    for i, e := range boundary {
+        if leftInside(e, boundary, &lonBands, &latBands) {
+            selected[i] = e
+        } else {
+            selected[i] = [2]Point{e[1], e[0]}
+        }
+    }
+    return assemblePolygons(chainRings(selected), mode)
+}
+
+// boundaryBands indexes boundary edges by their extent along one axis
+func boundaryBands(boundary [][2]Point, axis func(Point) float64) bandIndex {
+    lo, hi := math.Inf(1), math.Inf(-1)
+    for _, e := range boundary {
+        lo = math.Min(lo, math.Min(axis(e[0]), axis(e[1])))
+        hi = math.Max(hi, math.Max(axis(e[0]), axis(e[1])))
+    }
+    return newBandIndex(len(boundary), lo, hi, func(i int) (float64, float64) {
+        a, b := axis(boundary[i][0]), axis(boundary[i][1])
+        return math.Min(a, b), math.Max(a, b)
+    })
+}
+
+// leftInside reports whether the region to the left of e is inside the boundary
+// A ray from the middle of e runs north, or east for steep edges, and counts
+// crossings with the other boundary edges to decide which side of e is inside
+func leftInside(e [2]Point, boundary [][2]Point, lonBands, latBands *bandIndex) bool {
+    m := Point{Lat: (e[0].Lat + e[1].Lat) / 2, Lon: (e[0].Lon + e[1].Lon) / 2}
+    dx, dy := e[1].Lon-e[0].Lon, e[1].Lat-e[0].Lat
+    if math.Abs(dx) >= math.Abs(dy) {
+        // Left of an eastward edge is north
+        north := false
+        for _, k := range lonBands.at(m.Lon) {
+            f := boundary[k]
+            if f == e || (f[0].Lon > m.Lon) == (f[1].Lon > m.Lon) {
+                continue
+            }
+            lat := f[0].Lat + (m.Lon-f[0].Lon)*(f[1].Lat-f[0].Lat)/(f[1].Lon-f[0].Lon)
+            if lat > m.Lat {
+                north = !north
+            }
+        }
+        return north == (dx > 0)
+    }
+    // Left of a southward edge is east
+    east := false
+    for _, k := range latBands.at(m.Lat) {
+        f := boundary[k]
+        if f == e || (f[0].Lat > m.Lat) == (f[1].Lat > m.Lat) {
+            continue
+        }
+        lon := f[0].Lon + (m.Lat-f[0].Lat)*(f[1].Lon-f[0].Lon)/(f[1].Lat-f[0].Lat)
+        if lon > m.Lon {
+            east = !east
+        }
+    }
+    return east == (dy < 0)
+}
@@ -0,0 +1,44 @@
+package geoutil
+
+import (
+    "math/rand"
+    "testing"
+)
+
+func TestMakeValidSelfIntersecting(t *testing.T) {
+    star := starRing(Point{50, 10}, 5000, 3)
+    // Swapping two far-apart vertices makes the ring cross itself
+    star[100], star[2600] = star[2600], star[100]
+    tests := []struct {
+        name string
+        ring []Point
+    }{
+        {"bowtie", []Point{{0, 0}, {1, 1}, {1, 0}, {0, 1}}},
+        {"twisted star", star},
+    }
+    rng := rand.New(rand.NewSource(1))
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if ValidatePolygon(tt.ring, ValidateOptions{}) == nil {
+                t.Fatal("input unexpectedly valid")
+            }
+            mv := MakeValidPolygon(tt.ring)
+            if err := mv.Validate(ValidateOptions{}); err != nil {
+                t.Fatalf("repaired polygon invalid: %v", err)
+            }
+            box := ringRect(tt.ring)
+            for i := 0; i < 2000; i++ {
+                p := Point{
+                    Lat: box.minLat + rng.Float64()*(box.maxLat-box.minLat),
+                    Lon: box.minLon + rng.Float64()*(box.maxLon-box.minLon),
+                }
+                if ClassifyPoint(p, tt.ring, 1) == OnBoundary || mv.Classify(p, 1) == OnBoundary {
+                    continue
+                }
+                if got, want := mv.Contains(p), IsPointInPolygon(p, tt.ring); got != want {
+                    t.Fatalf("Contains(%v) = %v, want %v", p, got, want)
+                }
+            }
+        })
+    }
+}
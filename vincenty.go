@@ -0,0 +1,98 @@
+package geoutil
+
+import (
+    "errors"
+    "math"
+)
+
+// ErrNoConvergence is returned when an iterative geodesic method fails to converge
+// Vincenty's formulae do not converge for some nearly antipodal points
+var ErrNoConvergence = errors.New("geodesic iteration did not converge")
+
+// DistanceVincenty calculates ellipsoidal distance on WGS84 using Vincenty's inverse formula
+// Falls back to DistanceGeodesic for nearly antipodal points where Vincenty fails to converge
+// p1, p2: Geographic points
+// Returns: Distance in kilometers
+func DistanceVincenty(p1, p2 Point) float64 {
+    res, err := WGS84.InverseVincenty(p1, p2)
+    if err != nil {
+        return DistanceGeodesic(p1, p2)
+    }
+    return res.Distance
+}
+
+// InverseVincenty solves the inverse geodesic problem on WGS84 using Vincenty's formula
+// p1, p2: Geographic points
+// Returns: Distance with initial and final azimuths, ErrInvalidCoordinate or ErrNoConvergence
+func InverseVincenty(p1, p2 Point) (InverseResult, error) {
+    return WGS84.InverseVincenty(p1, p2)
+}
+
+// vincentyInverse implements Vincenty's inverse formula (Survey Review, 1975)
+// a: Semi-major axis in kilometers
+// f: Flattening
+func vincentyInverse(a, f float64, p1, p2 Point) (InverseResult, error) {
+    const (
+        maxIterations = 200
+        tolerance     = 1e-12
+    )
+
+    b := a * (1 - f)
+    L := angNormalize(p2.Lon-p1.Lon) * math.Pi / 180
+    tanU1 := (1 - f) * math.Tan(p1.Lat*math.Pi/180)
+    cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+    sinU1 := tanU1 * cosU1
+    tanU2 := (1 - f) * math.Tan(p2.Lat*math.Pi/180)
+    cosU2 := 1 / math.Sqrt(1+tanU2*tanU2)
+    sinU2 := tanU2 * cosU2
+
+    var sinλ, cosλ, sinσ, cosσ, σ, cosSqα, cos2σm float64
+    λ := L
+    converged := false
+    for i := 0; i < maxIterations; i++ {
+        sinλ, cosλ = math.Sincos(λ)
+        sinSqσ := (cosU2*sinλ)*(cosU2*sinλ) +
+            (cosU1*sinU2-sinU1*cosU2*cosλ)*(cosU1*sinU2-sinU1*cosU2*cosλ)
+        sinσ = math.Sqrt(sinSqσ)
+        if sinσ == 0 {
+            // Coincident points
+            return InverseResult{}, nil
+        }
+        cosσ = sinU1*sinU2 + cosU1*cosU2*cosλ
+        σ = math.Atan2(sinσ, cosσ)
+        sinα := cosU1 * cosU2 * sinλ / sinσ
+        cosSqα = 1 - sinα*sinα
+        cos2σm = 0 // Equatorial line
+        if cosSqα != 0 {
+            cos2σm = cosσ - 2*sinU1*sinU2/cosSqα
+        }
+        C := f / 16 * cosSqα * (4 + f*(4-3*cosSqα))
+        λPrev := λ
+        λ = L + (1-C)*f*sinα*(σ+C*sinσ*(cos2σm+C*cosσ*(-1+2*cos2σm*cos2σm)))
+        if math.Abs(λ) > math.Pi {
+            // Nearly antipodal points, λ has left its valid range
+            break
+        }
+        if math.Abs(λ-λPrev) <= tolerance {
+            converged = true
+            break
+        }
+    }
+    if !converged {
+        return InverseResult{}, ErrNoConvergence
+    }
+
+    uSq := cosSqα * (a*a - b*b) / (b * b)
+    A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+    B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+    Δσ := B * sinσ * (cos2σm + B/4*(cosσ*(-1+2*cos2σm*cos2σm)-
+        B/6*cos2σm*(-3+4*sinσ*sinσ)*(-3+4*cos2σm*cos2σm)))
+
+    α1 := math.Atan2(cosU2*sinλ, cosU1*sinU2-sinU1*cosU2*cosλ)
+    α2 := math.Atan2(cosU1*sinλ, -sinU1*cosU2+cosU1*sinU2*cosλ)
+    return InverseResult{
+        Distance:       b * A * (σ - Δσ),
+        InitialBearing: bearing360(α1 * 180 / math.Pi),
+        FinalBearing:   bearing360(α2 * 180 / math.Pi),
+    }, nil
+}
\ No newline at end of file